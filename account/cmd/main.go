@@ -4,16 +4,26 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	httpRouter "github.com/gabrielaraujr/golang-case/account/internal/adapters/http"
 	"github.com/gabrielaraujr/golang-case/account/internal/adapters/http/handler"
 	"github.com/gabrielaraujr/golang-case/account/internal/application/services"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/cardvault"
+	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/documentstore"
 	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/logger"
+	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/notification"
 	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/postgres"
 	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/queue"
+	"github.com/gabrielaraujr/golang-case/account/internal/infrastructure/webhook"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -29,17 +39,127 @@ func main() {
 
 	// Dependencies
 	producer, _ := queue.NewSQSProducer(queue.SQSConfig{
-		QueueURL: os.Getenv("SQS_PROPOSALS_QUEUE_URL"),
+		QueueURLs: map[string]string{
+			ports.QueueProposals:      os.Getenv("SQS_PROPOSALS_QUEUE_URL"),
+			ports.QueueProposalEvents: os.Getenv("SQS_PROPOSAL_EVENTS_QUEUE_URL"),
+		},
 	})
 	repo := postgres.NewProposalRepository(dbPool)
+	outboxRepo := postgres.NewOutboxRepository(dbPool)
+	idempotencyRepo := postgres.NewIdempotencyRepository(dbPool)
+	historyRepo := postgres.NewStatusHistoryRepository(dbPool)
+	txManager := postgres.NewTxManager(dbPool)
+	notificationLogRepo := postgres.NewNotificationLogRepository(dbPool)
+	webhookSubscriptionRepo := postgres.NewWebhookSubscriptionRepository(dbPool)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(dbPool)
+	eventBroker := postgres.NewProposalEventBroker(dbPool)
+	accountRepo := postgres.NewAccountRepository(dbPool)
+	cardRepo := postgres.NewCardRepository(dbPool)
+	offerEvidenceRepo := postgres.NewOfferEvidenceRepository(dbPool)
+	reviewDecisionRepo := postgres.NewReviewDecisionRepository(dbPool)
+	documentRepo := postgres.NewDocumentRepository(dbPool)
+	jobLock := postgres.NewAdvisoryLock(dbPool)
 	logger := logger.NewSimpleLogger()
 
+	// Notifications
+	var notifiers []ports.Notifier
+	if emailNotifier, err := notification.NewSMTPNotifier(notification.SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		From:     os.Getenv("SMTP_FROM"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		Timeout:  durationFromEnv("SMTP_TIMEOUT", notification.DefaultSMTPTimeout),
+	}); err == nil {
+		notifiers = append(notifiers, emailNotifier)
+	} else {
+		log.Printf("[Account] Email notifications disabled: %v", err)
+	}
+	if smsNotifier, err := notification.NewSMSNotifier(notification.SMSConfig{
+		APIURL: os.Getenv("SMS_API_URL"),
+		Sender: os.Getenv("SMS_SENDER"),
+	}); err == nil {
+		notifiers = append(notifiers, smsNotifier)
+	} else {
+		log.Printf("[Account] SMS notifications disabled: %v", err)
+	}
+	notificationService := services.NewNotificationService(notificationLogRepo, logger, notifiers...)
+	notificationQueue := services.NewNotificationQueue(notificationService, logger, services.NotificationQueueConfig{
+		Size:    intFromEnv("NOTIFICATION_QUEUE_SIZE", 256),
+		Workers: intFromEnv("NOTIFICATION_WORKERS", 4),
+	})
+
+	// Accounts and cards
+	cardTokenizer, err := cardvault.NewHMACTokenizer(os.Getenv("CARD_TOKEN_KEY"))
+	if err != nil {
+		log.Fatalf("Failed to configure card tokenizer: %v", err)
+	}
+	accountIssuer := services.NewAccountIssuer(accountRepo, cardRepo, cardTokenizer, services.AccountIssuerConfig{
+		Branch:  os.Getenv("ACCOUNT_BRANCH"),
+		CardBIN: os.Getenv("CARD_BIN"),
+	})
+
+	// Documents
+	documentStore, err := newDocumentStore()
+	if err != nil {
+		log.Fatalf("Failed to configure document store: %v", err)
+	}
+
 	// Use Cases
-	createUC := services.NewCreateProposalUseCase(repo, producer, logger)
+	// With ANALYSIS_AWAITS_DOCUMENTS=true, proposals are sent to risk-analysis
+	// once their documents are uploaded instead of on creation.
+	awaitDocuments := os.Getenv("ANALYSIS_AWAITS_DOCUMENTS") == "true"
+	eventPublisher := services.NewOutboxEventPublisher(outboxRepo)
+	webhookService := services.NewWebhookService(webhookSubscriptionRepo, webhookDeliveryRepo)
+	transitions := services.NewProposalTransitioner(repo, historyRepo, eventPublisher, webhookService, eventBroker, txManager, notificationQueue, logger)
+	createUC := services.NewCreateProposalUseCase(repo, eventPublisher, txManager, notificationQueue, logger, services.CreateProposalConfig{
+		MaxApplicantAge:       intFromEnv("MAX_APPLICANT_AGE", 0),
+		ReapplicationCooldown: durationFromEnv("REAPPLICATION_COOLDOWN", services.DefaultReapplicationCooldown),
+		AwaitDocuments:        awaitDocuments,
+	})
 	getUC := services.NewGetProposalUseCase(repo)
+	listUC := services.NewListProposalsUseCase(repo)
+	historyUC := services.NewGetProposalHistoryUseCase(repo, historyRepo)
+	streamUC := services.NewStreamProposalEventsUseCase(repo, eventBroker)
+	accountUC := services.NewGetProposalAccountUseCase(repo, accountRepo, cardRepo)
+	offerUC := services.NewRespondToOfferUseCase(repo, transitions, offerEvidenceRepo, accountIssuer, logger)
+	cancelUC := services.NewCancelProposalUseCase(repo, transitions, logger)
+	documentUC := services.NewUploadDocumentUseCase(repo, documentRepo, documentStore, eventPublisher, txManager, logger, services.UploadDocumentConfig{
+		MaxSize: int64(intFromEnv("DOCUMENT_MAX_SIZE", 0)),
+	})
+	offerConfig := services.OfferConfig{
+		AnnualFee:    moneyFromEnv("OFFER_ANNUAL_FEE", 0),
+		TermsVersion: os.Getenv("OFFER_TERMS_VERSION"),
+		TTL:          durationFromEnv("OFFER_TTL", services.DefaultOfferTTL),
+	}
+	reviewUC := services.NewReviewProposalUseCase(repo, transitions, reviewDecisionRepo, logger, offerConfig)
+
+	// Outbox relay
+	relay := services.NewOutboxRelay(outboxRepo, producer, txManager, logger, services.OutboxRelayConfig{})
+
+	// Webhook dispatcher
+	dispatcher := services.NewWebhookDispatcher(
+		webhookSubscriptionRepo,
+		webhookDeliveryRepo,
+		webhook.NewHTTPSender(10*time.Second),
+		logger,
+		services.WebhookDispatcherConfig{MaxAttempts: intFromEnv("WEBHOOK_MAX_ATTEMPTS", 8)},
+	)
+
+	// Offer expiry
+	offerExpirer := services.NewOfferExpirer(repo, transitions, logger, services.OfferExpirerConfig{})
+	idempotencyPurger := services.NewIdempotencyKeyPurger(idempotencyRepo, logger, services.IdempotencyKeyPurgerConfig{})
+
+	// Stuck proposals
+	stuckDetector := services.NewStuckProposalDetector(repo, documentRepo, eventPublisher, transitions, txManager, jobLock, logger, services.StuckProposalDetectorConfig{
+		PendingSLA:     durationFromEnv("PENDING_SLA", services.DefaultPendingSLA),
+		AnalyzingSLA:   durationFromEnv("ANALYZING_SLA", services.DefaultAnalyzingSLA),
+		MaxRepublishes: intFromEnv("ANALYSIS_MAX_REPUBLISHES", services.DefaultMaxRepublishes),
+		AwaitDocuments: awaitDocuments,
+	})
 
 	// Consumer
-	eventHandler := services.NewProposalStatusChangedEventHandler(repo, logger)
+	eventHandler := services.NewProposalStatusChangedEventHandler(repo, transitions, logger, offerConfig)
 	consumer, _ := queue.NewSQSConsumer(queue.SQSConsumerConfig{
 		QueueURL:    os.Getenv("SQS_RISK_QUEUE_URL"),
 		MaxMessages: 10,
@@ -48,12 +168,50 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	_ = notificationQueue.Start(ctx)
+	log.Println("[Account] Notification workers started")
+
+	_ = eventBroker.Start(ctx)
+	log.Println("[Account] Proposal event listener started")
+
 	_ = consumer.Start(ctx)
 	log.Println("[Account] Consumer started")
 
+	_ = relay.Start(ctx)
+	log.Println("[Account] Outbox relay started")
+
+	_ = dispatcher.Start(ctx)
+	log.Println("[Account] Webhook dispatcher started")
+
+	_ = offerExpirer.Start(ctx)
+	log.Println("[Account] Offer expirer started")
+
+	_ = idempotencyPurger.Start(ctx)
+	log.Println("[Account] Idempotency key purger started")
+
+	_ = stuckDetector.Start(ctx)
+	log.Println("[Account] Stuck proposal detector started")
+
 	// HTTP Server
 	port := os.Getenv("PORT")
-	router := httpRouter.NewRouter(handler.NewProposalHandler(createUC, getUC))
+	idempotencyConfig := handler.IdempotencyConfig{
+		TTL:   durationFromEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		Lease: durationFromEnv("IDEMPOTENCY_KEY_LEASE", time.Minute),
+	}
+	router := httpRouter.NewRouter(httpRouter.Handlers{
+		Proposal:    handler.NewProposalHandler(createUC, getUC, listUC),
+		History:     handler.NewHistoryHandler(historyUC),
+		Events:      handler.NewEventStreamHandler(streamUC),
+		Account:     handler.NewAccountHandler(accountUC),
+		Offer:       handler.NewOfferHandler(offerUC, prefixesFromEnv("TRUSTED_PROXIES")),
+		Cancel:      handler.NewCancelHandler(cancelUC),
+		Document:    handler.NewDocumentHandler(documentUC),
+		Review:      handler.NewReviewHandler(reviewUC, listUC),
+		Outbox:      handler.NewOutboxHandler(relay),
+		Webhook:     handler.NewWebhookHandler(webhookService),
+		Idempotency: handler.Idempotency(idempotencyRepo, idempotencyConfig),
+		Trace:       handler.Trace(logger),
+	})
 	go func() {
 		log.Printf("[Account] Server listening on :%s", port)
 		if err := http.ListenAndServe(":"+port, router); err != nil {
@@ -68,4 +226,74 @@ func main() {
 
 	log.Println("[Account] Shutting down...")
 	_ = consumer.Stop()
+	_ = relay.Stop()
+	_ = dispatcher.Stop()
+	_ = offerExpirer.Stop()
+	_ = idempotencyPurger.Stop()
+	_ = stuckDetector.Stop()
+	_ = notificationQueue.Stop()
+	_ = eventBroker.Stop()
+}
+
+// newDocumentStore keeps documents in an S3-compatible bucket when
+// DOCUMENT_STORE is "s3", and on the local filesystem otherwise.
+func newDocumentStore() (ports.DocumentStore, error) {
+	if os.Getenv("DOCUMENT_STORE") == "s3" {
+		return documentstore.NewS3Store(documentstore.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	}
+	dir := os.Getenv("DOCUMENT_DIR")
+	if dir == "" {
+		dir = "documents"
+	}
+	return documentstore.NewLocalStore(dir)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// prefixesFromEnv parses a comma-separated list of CIDRs or single
+// addresses. Invalid entries are skipped.
+func prefixesFromEnv(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		} else {
+			log.Printf("[Account] ignoring invalid %s entry %q", key, entry)
+		}
+	}
+	return prefixes
+}
+
+func moneyFromEnv(key string, fallback money.Money) money.Money {
+	value, err := money.Parse(os.Getenv(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
 }
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type getProposalAccountExecutor interface {
+	Execute(ctx context.Context, id uuid.UUID) (*dto.AccountResponse, error)
+}
+
+type AccountHandler struct {
+	accountUseCase getProposalAccountExecutor
+}
+
+func NewAccountHandler(accountUseCase getProposalAccountExecutor) *AccountHandler {
+	return &AccountHandler{accountUseCase: accountUseCase}
+}
+
+func (h *AccountHandler) GetByProposalID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	response, err := h.accountUseCase.Execute(r.Context(), id)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
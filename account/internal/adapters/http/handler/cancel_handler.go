@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type cancelProposalExecutor interface {
+	Execute(ctx context.Context, id uuid.UUID, req *dto.CancelProposalRequest) (*dto.ProposalResponse, error)
+}
+
+type CancelHandler struct {
+	useCase cancelProposalExecutor
+}
+
+func NewCancelHandler(useCase cancelProposalExecutor) *CancelHandler {
+	return &CancelHandler{useCase: useCase}
+}
+
+func (h *CancelHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	var req dto.CancelProposalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	response, err := h.useCase.Execute(r.Context(), id, &req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxDocumentRequestBytes caps the multipart body. The document size limit
+// itself is enforced by the use case.
+const maxDocumentRequestBytes = 32 << 20
+
+type uploadDocumentExecutor interface {
+	Execute(ctx context.Context, proposalID uuid.UUID, req *dto.UploadDocumentRequest) (*dto.DocumentResponse, error)
+}
+
+type DocumentHandler struct {
+	useCase uploadDocumentExecutor
+}
+
+func NewDocumentHandler(useCase uploadDocumentExecutor) *DocumentHandler {
+	return &DocumentHandler{useCase: useCase}
+}
+
+// Upload expects a multipart form with the document type in "type" and the
+// file in "file".
+func (h *DocumentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentRequestBytes)
+	if err := r.ParseMultipartForm(maxDocumentRequestBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body is too large")
+			return
+		}
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_MULTIPART", "request body must be multipart/form-data")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "FILE_REQUIRED", "a file is required in the file field")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_MULTIPART", "could not read the uploaded file")
+		return
+	}
+
+	response, err := h.useCase.Execute(r.Context(), id, &dto.UploadDocumentRequest{
+		Type:     r.FormValue("type"),
+		FileName: header.Filename,
+		Content:  content,
+	})
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, response)
+}
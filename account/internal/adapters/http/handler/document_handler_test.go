@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type mockUploadDocumentUseCase struct {
+	received *dto.UploadDocumentRequest
+}
+
+func (m *mockUploadDocumentUseCase) Execute(ctx context.Context, proposalID uuid.UUID, req *dto.UploadDocumentRequest) (*dto.DocumentResponse, error) {
+	m.received = req
+	return &dto.DocumentResponse{ProposalID: proposalID, Type: req.Type, FileName: req.FileName}, nil
+}
+
+func newUploadRequest(t *testing.T, proposalID uuid.UUID, fields map[string]string, fileName string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(content)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/proposals/"+proposalID.String()+"/documents", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	routerContext := chi.NewRouteContext()
+	routerContext.URLParams.Add("id", proposalID.String())
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routerContext))
+}
+
+func TestDocumentHandler_Upload(t *testing.T) {
+	t.Run("should pass the type and file to the use case", func(t *testing.T) {
+		useCase := &mockUploadDocumentUseCase{}
+		rec := httptest.NewRecorder()
+
+		NewDocumentHandler(useCase).Upload(rec, newUploadRequest(t, uuid.New(), map[string]string{"type": "identity"}, "rg.pdf", []byte("%PDF-1.4")))
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body)
+		}
+		if useCase.received.Type != "identity" || useCase.received.FileName != "rg.pdf" || string(useCase.received.Content) != "%PDF-1.4" {
+			t.Errorf("unexpected request %+v", useCase.received)
+		}
+	})
+
+	tests := []struct {
+		name string
+		req  func() *http.Request
+		code string
+	}{
+		{
+			name: "missing file",
+			req: func() *http.Request {
+				return newUploadRequest(t, uuid.New(), map[string]string{"type": "identity"}, "", nil)
+			},
+			code: "FILE_REQUIRED",
+		},
+		{
+			name: "json body",
+			req: func() *http.Request {
+				req := newUploadRequest(t, uuid.New(), nil, "", nil)
+				req.Body = http.NoBody
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			code: "INVALID_MULTIPART",
+		},
+		{
+			name: "body over the request limit",
+			req: func() *http.Request {
+				return newUploadRequest(t, uuid.New(), nil, "big.pdf", []byte(strings.Repeat("x", maxDocumentRequestBytes+1)))
+			},
+			code: "PAYLOAD_TOO_LARGE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			useCase := &mockUploadDocumentUseCase{}
+			rec := httptest.NewRecorder()
+
+			NewDocumentHandler(useCase).Upload(rec, tt.req())
+
+			var errResponse problem
+			json.NewDecoder(rec.Body).Decode(&errResponse)
+			if errResponse.Code != tt.code {
+				t.Errorf("expected code %s, got %q (status %d)", tt.code, errResponse.Code, rec.Code)
+			}
+			if useCase.received != nil {
+				t.Error("expected use case not to be called")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+type streamProposalEventsExecutor interface {
+	Execute(ctx context.Context, id uuid.UUID) (<-chan *dto.ProposalEventResponse, error)
+}
+
+type EventStreamHandler struct {
+	streamUseCase streamProposalEventsExecutor
+}
+
+func NewEventStreamHandler(streamUseCase streamProposalEventsExecutor) *EventStreamHandler {
+	return &EventStreamHandler{streamUseCase: streamUseCase}
+}
+
+// Stream sends proposal events as Server-Sent Events. The response ends after
+// the event with "final": true, so clients should close their EventSource
+// when they receive it.
+func (h *EventStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "streaming not supported")
+		return
+	}
+
+	events, err := h.streamUseCase.Execute(r.Context(), id)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+	defaultIdempotencyLease   = time.Minute
+)
+
+type IdempotencyConfig struct {
+	// TTL is how long a completed response is replayed.
+	TTL time.Duration
+	// Lease is how long an in-flight reservation blocks retries. A request
+	// that crashed or timed out without completing frees the key after it.
+	Lease time.Duration
+}
+
+// Idempotency replays the stored response for retries carrying the same
+// Idempotency-Key and request body. Server errors are not stored, so the
+// client can retry them with the same key.
+func Idempotency(store ports.IdempotencyRepository, cfg IdempotencyConfig) func(http.Handler) http.Handler {
+	if cfg.Lease == 0 {
+		cfg.Lease = defaultIdempotencyLease
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeProblem(w, r, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY", "idempotency key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					writeProblem(w, r, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body is too large")
+					return
+				}
+				writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			record := &ports.IdempotencyRecord{
+				Key:         key,
+				RequestHash: hashRequest(r, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(cfg.Lease),
+			}
+
+			existing, err := store.Reserve(r.Context(), record)
+			if err != nil {
+				writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "unexpected error")
+				return
+			}
+			if existing != nil {
+				replay(w, r, existing, record.RequestHash)
+				return
+			}
+
+			rec := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				_ = store.Release(r.Context(), record)
+			} else {
+				record.StatusCode = rec.status
+				record.ContentType = rec.header.Get("Content-Type")
+				record.ResponseBody = rec.body.Bytes()
+				record.ExpiresAt = time.Now().Add(cfg.TTL)
+				if err := store.Complete(r.Context(), record); err != nil {
+					_ = store.Release(r.Context(), record)
+				}
+			}
+
+			rec.flush(w)
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, r *http.Request, existing *ports.IdempotencyRecord, requestHash string) {
+	if existing.RequestHash != requestHash {
+		writeProblem(w, r, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "idempotency key was already used with a different request")
+		return
+	}
+	if existing.StatusCode == 0 {
+		writeProblem(w, r, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", "a request with this idempotency key is still being processed")
+		return
+	}
+
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(existing.StatusCode)
+	w.Write(existing.ResponseBody)
+}
+
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder buffers the response so it can be stored before being sent.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.status)
+	w.Write(r.body.Bytes())
+}
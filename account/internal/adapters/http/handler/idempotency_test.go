@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type mockIdempotencyRepository struct {
+	records map[string]*ports.IdempotencyRecord
+}
+
+func newMockIdempotencyRepository() *mockIdempotencyRepository {
+	return &mockIdempotencyRepository{records: map[string]*ports.IdempotencyRecord{}}
+}
+
+func (m *mockIdempotencyRepository) Reserve(ctx context.Context, record *ports.IdempotencyRecord) (*ports.IdempotencyRecord, error) {
+	if existing, ok := m.records[record.Key]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return existing, nil
+	}
+	stored := *record
+	m.records[record.Key] = &stored
+	return nil, nil
+}
+
+func (m *mockIdempotencyRepository) Complete(ctx context.Context, record *ports.IdempotencyRecord) error {
+	if existing, ok := m.records[record.Key]; ok && existing.CreatedAt.Equal(record.CreatedAt) {
+		stored := *record
+		m.records[record.Key] = &stored
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Release(ctx context.Context, record *ports.IdempotencyRecord) error {
+	if existing, ok := m.records[record.Key]; ok && existing.CreatedAt.Equal(record.CreatedAt) {
+		delete(m.records, record.Key)
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	return 0, nil
+}
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotency(t *testing.T) {
+	t.Run("should replay stored response for identical retry", func(t *testing.T) {
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour})(next)
+
+		first := httptest.NewRecorder()
+		mw.ServeHTTP(first, newIdempotentRequest("key-1", `{"cpf":"1"}`))
+		retry := httptest.NewRecorder()
+		mw.ServeHTTP(retry, newIdempotentRequest("key-1", `{"cpf":"1"}`))
+
+		if calls != 1 {
+			t.Errorf("expected handler to run once, ran %d times", calls)
+		}
+		if retry.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d", retry.Code)
+		}
+		if retry.Body.String() != first.Body.String() {
+			t.Errorf("expected replayed body %q, got %q", first.Body.String(), retry.Body.String())
+		}
+		if retry.Header().Get(IdempotentReplayedHeader) != "true" {
+			t.Error("expected replayed header on retry")
+		}
+	})
+
+	t.Run("should return 422 when key is reused with a different body", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour})(next)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"cpf":"1"}`))
+		rec := httptest.NewRecorder()
+		mw.ServeHTTP(rec, newIdempotentRequest("key-1", `{"cpf":"2"}`))
+
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should not store server errors", func(t *testing.T) {
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "unexpected error")
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour})(next)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+
+		if calls != 2 {
+			t.Errorf("expected handler to run twice, ran %d times", calls)
+		}
+	})
+
+	t.Run("should run handler again after key expires", func(t *testing.T) {
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: -time.Second})(next)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+
+		if calls != 2 {
+			t.Errorf("expected handler to run twice, ran %d times", calls)
+		}
+	})
+
+	t.Run("should pass through requests without key", func(t *testing.T) {
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour})(next)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("", `{}`))
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("", `{}`))
+
+		if calls != 2 {
+			t.Errorf("expected handler to run twice, ran %d times", calls)
+		}
+	})
+	t.Run("should return 413 when the body is too large", func(t *testing.T) {
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		})
+		mw := Idempotency(newMockIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour})(next)
+
+		rec := httptest.NewRecorder()
+		mw.ServeHTTP(rec, newIdempotentRequest("key-1", strings.Repeat("a", maxIdempotentRequestBytes+1)))
+
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413, got %d", rec.Code)
+		}
+		if calls != 0 {
+			t.Errorf("expected handler not to run, ran %d times", calls)
+		}
+	})
+
+	t.Run("should return 409 while the reservation lease is active", func(t *testing.T) {
+		store := newMockIdempotencyRepository()
+		store.records["key-1"] = &ports.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: hashRequest(newIdempotentRequest("key-1", `{}`), []byte(`{}`)),
+			CreatedAt:   time.Now(),
+			ExpiresAt:   time.Now().Add(time.Minute),
+		}
+		mw := Idempotency(store, IdempotencyConfig{TTL: time.Hour})(http.NotFoundHandler())
+
+		rec := httptest.NewRecorder()
+		mw.ServeHTTP(rec, newIdempotentRequest("key-1", `{}`))
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should take over a reservation whose lease expired", func(t *testing.T) {
+		store := newMockIdempotencyRepository()
+		store.records["key-1"] = &ports.IdempotencyRecord{
+			Key:         "key-1",
+			RequestHash: hashRequest(newIdempotentRequest("key-1", `{}`), []byte(`{}`)),
+			CreatedAt:   time.Now().Add(-2 * time.Minute),
+			ExpiresAt:   time.Now().Add(-time.Minute),
+		}
+		calls := 0
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(store, IdempotencyConfig{TTL: time.Hour, Lease: time.Minute})(next)
+
+		rec := httptest.NewRecorder()
+		mw.ServeHTTP(rec, newIdempotentRequest("key-1", `{}`))
+
+		if rec.Code != http.StatusCreated || calls != 1 {
+			t.Errorf("expected handler to run once with 201, got %d after %d calls", rec.Code, calls)
+		}
+		stored := store.records["key-1"]
+		if stored.StatusCode != http.StatusCreated || time.Until(stored.ExpiresAt) < 59*time.Minute {
+			t.Errorf("expected completed record to last the TTL, got %+v", stored)
+		}
+	})
+
+	t.Run("should not overwrite a reservation taken over after the lease expired", func(t *testing.T) {
+		store := newMockIdempotencyRepository()
+		var takenOver *ports.IdempotencyRecord
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			takenOver = &ports.IdempotencyRecord{Key: "key-1", CreatedAt: time.Now().Add(time.Second), ExpiresAt: time.Now().Add(time.Minute)}
+			store.records["key-1"] = takenOver
+			writeJSON(w, http.StatusCreated, map[string]string{"id": "abc"})
+		})
+		mw := Idempotency(store, IdempotencyConfig{TTL: time.Hour})(next)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+
+		if store.records["key-1"] != takenOver || takenOver.StatusCode != 0 {
+			t.Errorf("expected the newer reservation to be kept, got %+v", store.records["key-1"])
+		}
+	})
+}
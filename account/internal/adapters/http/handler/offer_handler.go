@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type offerResponder interface {
+	Accept(ctx context.Context, id uuid.UUID, req *dto.OfferDecisionRequest) (*dto.ProposalResponse, error)
+	Decline(ctx context.Context, id uuid.UUID, req *dto.OfferDecisionRequest) (*dto.ProposalResponse, error)
+}
+
+// OfferHandler records the caller's address as evidence of the answer. The
+// X-Forwarded-For header is only kept when the request comes from one of
+// trustedProxies, since any client can send it.
+type OfferHandler struct {
+	offers         offerResponder
+	trustedProxies []netip.Prefix
+}
+
+func NewOfferHandler(offers offerResponder, trustedProxies []netip.Prefix) *OfferHandler {
+	return &OfferHandler{offers: offers, trustedProxies: trustedProxies}
+}
+
+func (h *OfferHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.offers.Accept)
+}
+
+func (h *OfferHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.offers.Decline)
+}
+
+func (h *OfferHandler) respond(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(ctx context.Context, id uuid.UUID, req *dto.OfferDecisionRequest) (*dto.ProposalResponse, error),
+) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	// The body is optional when declining.
+	var req dto.OfferDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+	req.IPAddress = remoteIP(r)
+	if h.fromTrustedProxy(req.IPAddress) {
+		req.ForwardedFor = r.Header.Get("X-Forwarded-For")
+	}
+	req.UserAgent = r.UserAgent()
+
+	response, err := decide(r.Context(), id, &req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// remoteIP returns the address of the connection the request came from.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (h *OfferHandler) fromTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	for _, proxy := range h.trustedProxies {
+		if proxy.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
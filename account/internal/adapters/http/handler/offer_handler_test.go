@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type mockOfferResponder struct {
+	received *dto.OfferDecisionRequest
+}
+
+func (m *mockOfferResponder) Accept(ctx context.Context, id uuid.UUID, req *dto.OfferDecisionRequest) (*dto.ProposalResponse, error) {
+	m.received = req
+	return &dto.ProposalResponse{ID: id}, nil
+}
+
+func (m *mockOfferResponder) Decline(ctx context.Context, id uuid.UUID, req *dto.OfferDecisionRequest) (*dto.ProposalResponse, error) {
+	m.received = req
+	return &dto.ProposalResponse{ID: id}, nil
+}
+
+func newOfferRequest(remoteAddr, forwardedFor string) *http.Request {
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodPost, "/proposals/"+id.String()+"/offer/accept", strings.NewReader(`{"terms_version":"v1"}`))
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	req.Header.Set("User-Agent", "test-agent")
+	routerContext := chi.NewRouteContext()
+	routerContext.URLParams.Add("id", id.String())
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routerContext))
+}
+
+func TestOfferHandler_Accept(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	t.Run("should store the remote address and ignore a forged forwarded header", func(t *testing.T) {
+		offers := &mockOfferResponder{}
+		rec := httptest.NewRecorder()
+
+		NewOfferHandler(offers, trustedProxies).Accept(rec, newOfferRequest("203.0.113.7:51234", "198.51.100.1"))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body)
+		}
+		if offers.received.IPAddress != "203.0.113.7" {
+			t.Errorf("expected the remote address, got %q", offers.received.IPAddress)
+		}
+		if offers.received.ForwardedFor != "" {
+			t.Errorf("expected the forwarded header to be ignored, got %q", offers.received.ForwardedFor)
+		}
+		if offers.received.TermsVersion != "v1" || offers.received.UserAgent != "test-agent" {
+			t.Errorf("unexpected request %+v", offers.received)
+		}
+	})
+
+	t.Run("should keep the forwarded header from a trusted proxy", func(t *testing.T) {
+		offers := &mockOfferResponder{}
+		rec := httptest.NewRecorder()
+
+		NewOfferHandler(offers, trustedProxies).Accept(rec, newOfferRequest("10.1.2.3:443", "198.51.100.1"))
+
+		if offers.received.IPAddress != "10.1.2.3" || offers.received.ForwardedFor != "198.51.100.1" {
+			t.Errorf("expected proxy address and forwarded header, got %+v", offers.received)
+		}
+	})
+}
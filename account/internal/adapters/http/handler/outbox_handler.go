@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type outboxStatsProvider interface {
+	Stats(ctx context.Context) (*ports.OutboxStats, error)
+}
+
+type OutboxHandler struct {
+	stats outboxStatsProvider
+}
+
+func NewOutboxHandler(stats outboxStatsProvider) *OutboxHandler {
+	return &OutboxHandler{stats: stats}
+}
+
+type outboxStatsResponse struct {
+	Pending       int        `json:"pending"`
+	Stuck         int        `json:"stuck"`
+	OldestPending *time.Time `json:"oldest_pending,omitempty"`
+}
+
+func (h *OutboxHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.stats.Stats(r.Context())
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load outbox stats")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, outboxStatsResponse{
+		Pending:       stats.Pending,
+		Stuck:         stats.Stuck,
+		OldestPending: stats.OldestPending,
+	})
+}
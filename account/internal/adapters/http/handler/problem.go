@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+
+	// problemTypeBaseURI points to the error catalog; the problem code, in
+	// lower case, is the anchor of each entry.
+	problemTypeBaseURI = "https://github.com/gabrielaraujr/golang-case/blob/main/docs/erros.md#"
+)
+
+// problem is an RFC 7807 error response. Code and Fields are extension
+// members: Code is the stable identifier clients switch on, Fields lists
+// the invalid request fields of a validation error.
+type problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	TraceID  string                 `json:"trace_id,omitempty"`
+	Code     string                 `json:"code"`
+	Fields   []appErrors.FieldError `json:"fields,omitempty"`
+}
+
+// handleApplicationError renders err as a problem. Only the public message of
+// an ApplicationError reaches the client; the wrapped cause is logged with
+// the trace id so it can be found from the response.
+func handleApplicationError(w http.ResponseWriter, r *http.Request, err error) {
+	trace := traceFrom(r.Context())
+
+	var appErr *appErrors.ApplicationError
+	if !errors.As(err, &appErr) {
+		logProblemCause(r, trace, http.StatusInternalServerError, "INTERNAL_ERROR", err)
+		writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "unexpected error")
+		return
+	}
+
+	if appErr.Err != nil {
+		logProblemCause(r, trace, appErr.StatusCode, appErr.Code, appErr.Err)
+	}
+	p := newProblem(r, appErr.StatusCode, appErr.Code, appErr.Message)
+	p.Fields = appErr.Fields
+	writeProblemJSON(w, p)
+}
+
+func logProblemCause(r *http.Request, trace *requestTrace, status int, code string, cause error) {
+	if trace.logger == nil {
+		return
+	}
+	args := []any{"trace_id", trace.id, "method", r.Method, "path", r.URL.Path, "status", status, "code", code, "error", cause}
+	if status >= http.StatusInternalServerError {
+		trace.logger.Error(r.Context(), "request failed", args...)
+		return
+	}
+	trace.logger.Warn(r.Context(), "request rejected", args...)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	writeProblemJSON(w, newProblem(r, status, code, detail))
+}
+
+func newProblem(r *http.Request, status int, code, detail string) *problem {
+	return &problem{
+		Type:     problemTypeBaseURI + strings.ToLower(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		TraceID:  traceFrom(r.Context()).id,
+		Code:     code,
+	}
+}
+
+func writeProblemJSON(w http.ResponseWriter, p *problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// NotFound and MethodNotAllowed answer unknown routes with a problem too.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusNotFound, "ROUTE_NOT_FOUND", "no route matches "+r.URL.Path)
+}
+
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", r.Method+" is not allowed on "+r.URL.Path)
+}
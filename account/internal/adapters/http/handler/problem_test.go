@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+)
+
+type recordingLogger struct {
+	errors []string
+}
+
+func (l *recordingLogger) Info(ctx context.Context, msg string, args ...any) {}
+
+func (l *recordingLogger) Warn(ctx context.Context, msg string, args ...any) {}
+
+func (l *recordingLogger) Error(ctx context.Context, msg string, args ...any) {
+	l.errors = append(l.errors, fmt.Sprint(append([]any{msg}, args...)...))
+}
+
+func serveTraced(logger *recordingLogger, next http.HandlerFunc, req *http.Request) (*httptest.ResponseRecorder, problem) {
+	rec := httptest.NewRecorder()
+	Trace(logger)(next).ServeHTTP(rec, req)
+
+	var body problem
+	json.NewDecoder(rec.Body).Decode(&body)
+	return rec, body
+}
+
+func TestProblemResponses(t *testing.T) {
+	t.Run("should render application errors as problem+json", func(t *testing.T) {
+		next := func(w http.ResponseWriter, r *http.Request) {
+			handleApplicationError(w, r, appErrors.NewNotFoundError("proposal"))
+		}
+		req := httptest.NewRequest(http.MethodGet, "/proposals/123?x=1", nil)
+
+		rec, body := serveTraced(&recordingLogger{}, next, req)
+
+		if got := rec.Header().Get("Content-Type"); got != ProblemContentType {
+			t.Errorf("expected content type %q, got %q", ProblemContentType, got)
+		}
+		if body.Status != http.StatusNotFound || body.Title != "Not Found" || body.Code != "NOT_FOUND" {
+			t.Errorf("unexpected problem %+v", body)
+		}
+		if !strings.HasSuffix(body.Type, "#not_found") {
+			t.Errorf("expected type to point to the not_found entry, got %q", body.Type)
+		}
+		if body.Detail != "proposal not found" || body.Instance != "/proposals/123" {
+			t.Errorf("unexpected detail %q or instance %q", body.Detail, body.Instance)
+		}
+		if body.TraceID == "" || body.TraceID != rec.Header().Get(TraceIDHeader) {
+			t.Errorf("expected trace id %q to match header %q", body.TraceID, rec.Header().Get(TraceIDHeader))
+		}
+	})
+
+	t.Run("should log internal causes without sending them to the client", func(t *testing.T) {
+		logger := &recordingLogger{}
+		next := func(w http.ResponseWriter, r *http.Request) {
+			cause := errors.New(`ERROR: relation "proposals" does not exist (SQLSTATE 42P01)`)
+			handleApplicationError(w, r, appErrors.NewInternalError("failed to fetch proposal", cause))
+		}
+
+		rec, body := serveTraced(logger, next, httptest.NewRequest(http.MethodGet, "/proposals", nil))
+
+		if rec.Code != http.StatusInternalServerError || body.Detail != "failed to fetch proposal" {
+			t.Errorf("expected 500 with public detail, got %d %q", rec.Code, body.Detail)
+		}
+		if strings.Contains(rec.Body.String(), "SQLSTATE") {
+			t.Errorf("internal cause leaked to the client: %s", rec.Body.String())
+		}
+		if len(logger.errors) != 1 || !strings.Contains(logger.errors[0], "SQLSTATE") || !strings.Contains(logger.errors[0], body.TraceID) {
+			t.Errorf("expected cause logged with trace id %q, got %v", body.TraceID, logger.errors)
+		}
+	})
+
+	t.Run("should hide errors that are not application errors", func(t *testing.T) {
+		next := func(w http.ResponseWriter, r *http.Request) {
+			handleApplicationError(w, r, errors.New("connection refused"))
+		}
+
+		rec, body := serveTraced(&recordingLogger{}, next, httptest.NewRequest(http.MethodGet, "/proposals", nil))
+
+		if body.Code != "INTERNAL_ERROR" || strings.Contains(rec.Body.String(), "connection refused") {
+			t.Errorf("expected generic internal error, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("should reuse the trace id from traceparent", func(t *testing.T) {
+		next := func(w http.ResponseWriter, r *http.Request) {
+			writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		}
+		req := httptest.NewRequest(http.MethodGet, "/proposals/abc", nil)
+		req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		_, body := serveTraced(&recordingLogger{}, next, req)
+
+		if body.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("expected trace id from traceparent, got %q", body.TraceID)
+		}
+	})
+
+	t.Run("should answer panics with a problem", func(t *testing.T) {
+		logger := &recordingLogger{}
+		next := func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}
+
+		rec, body := serveTraced(logger, next, httptest.NewRequest(http.MethodGet, "/proposals", nil))
+
+		if rec.Code != http.StatusInternalServerError || body.Code != "INTERNAL_ERROR" {
+			t.Errorf("expected 500 INTERNAL_ERROR, got %d %q", rec.Code, body.Code)
+		}
+		if len(logger.errors) != 1 {
+			t.Errorf("expected panic to be logged, got %v", logger.errors)
+		}
+	})
+}
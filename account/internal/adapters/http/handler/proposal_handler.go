@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
 	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
@@ -20,31 +22,46 @@ type getProposalExecutor interface {
 	Execute(ctx context.Context, id uuid.UUID) (*dto.ProposalResponse, error)
 }
 
+type listProposalsExecutor interface {
+	Execute(ctx context.Context, req *dto.ListProposalsRequest) (*dto.ProposalListResponse, error)
+}
+
 type ProposalHandler struct {
 	createUseCase createProposalExecutor
 	getUseCase    getProposalExecutor
+	listUseCase   listProposalsExecutor
 }
 
 func NewProposalHandler(
 	createUseCase createProposalExecutor,
 	getUseCase getProposalExecutor,
+	listUseCase listProposalsExecutor,
 ) *ProposalHandler {
 	return &ProposalHandler{
 		createUseCase: createUseCase,
 		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
 	}
 }
 
 func (h *ProposalHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateProposalRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		// The salary is parsed while decoding, so a malformed amount is
+		// reported as a field error rather than as invalid JSON.
+		if errors.Is(err, money.ErrInvalidAmount) {
+			handleApplicationError(w, r, appErrors.NewValidationError([]appErrors.FieldError{
+				{Pointer: "/salary", Code: "SALARY_INVALID", Message: err.Error()},
+			}))
+			return
+		}
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
 		return
 	}
 
 	response, err := h.createUseCase.Execute(r.Context(), &req)
 	if err != nil {
-		handleApplicationError(w, err)
+		handleApplicationError(w, r, err)
 		return
 	}
 
@@ -55,26 +72,46 @@ func (h *ProposalHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
 		return
 	}
 
 	response, err := h.getUseCase.Execute(r.Context(), id)
 	if err != nil {
-		handleApplicationError(w, err)
+		handleApplicationError(w, r, err)
 		return
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
-func handleApplicationError(w http.ResponseWriter, err error) {
-	var appErr *appErrors.ApplicationError
-	if errors.As(err, &appErr) {
-		writeError(w, appErr.StatusCode, appErr.Code, appErr.Error())
+func (h *ProposalHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &dto.ListProposalsRequest{
+		Status:      query.Get("status"),
+		CreatedFrom: query.Get("created_from"),
+		CreatedTo:   query.Get("created_to"),
+		State:       query.Get("state"),
+		CPFPrefix:   query.Get("cpf_prefix"),
+		Sort:        query.Get("sort"),
+		Cursor:      query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "INVALID_LIMIT", "limit must be a number")
+			return
+		}
+		req.Limit = value
+	}
+
+	response, err := h.listUseCase.Execute(r.Context(), req)
+	if err != nil {
+		handleApplicationError(w, r, err)
 		return
 	}
-	writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "unexpected error")
+
+	writeJSON(w, http.StatusOK, response)
 }
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -82,12 +119,3 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{
-		"code":    code,
-		"message": message,
-	})
-}
@@ -11,6 +11,7 @@ import (
 
 	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
 	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
@@ -37,13 +38,24 @@ func (m *mockGetProposalUseCase) Execute(ctx context.Context, id uuid.UUID) (*dt
 	return nil, nil
 }
 
+type mockListProposalsUseCase struct {
+	executeFn func(ctx context.Context, req *dto.ListProposalsRequest) (*dto.ProposalListResponse, error)
+}
+
+func (m *mockListProposalsUseCase) Execute(ctx context.Context, req *dto.ListProposalsRequest) (*dto.ProposalListResponse, error) {
+	if m.executeFn != nil {
+		return m.executeFn(ctx, req)
+	}
+	return &dto.ProposalListResponse{}, nil
+}
+
 func TestProposalHandler_Create(t *testing.T) {
 	t.Run("should return 201 when proposal is created successfully", func(t *testing.T) {
 		proposalID := uuid.New()
 		expectedResponse := &dto.ProposalResponse{
 			ID:        proposalID,
 			FullName:  "John Doe",
-			CPF:       "12345678901",
+			CPF:       "12345678909",
 			Email:     "john@example.com",
 			Phone:     "11999999999",
 			BirthDate: time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC),
@@ -64,11 +76,11 @@ func TestProposalHandler_Create(t *testing.T) {
 			},
 		}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		reqBody := `{
 			"full_name": "John Doe",
-			"cpf": "12345678901",
+			"cpf": "12345678909",
 			"email": "john@example.com",
 			"phone": "11999999999",
 			"birthdate": "15-01-1990",
@@ -108,7 +120,7 @@ func TestProposalHandler_Create(t *testing.T) {
 	t.Run("should return 400 when JSON is invalid", func(t *testing.T) {
 		createUseCase := &mockCreateProposalUseCase{}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString("{invalid json"))
 		rec := httptest.NewRecorder()
@@ -119,11 +131,59 @@ func TestProposalHandler_Create(t *testing.T) {
 			t.Errorf("expected status 400, got %d", rec.Code)
 		}
 
-		var errResponse map[string]string
+		var errResponse problem
+		json.NewDecoder(rec.Body).Decode(&errResponse)
+
+		if errResponse.Code != "INVALID_JSON" {
+			t.Errorf("expected code INVALID_JSON, got %q", errResponse.Code)
+		}
+	})
+
+	t.Run("should accept the salary as a number or a string", func(t *testing.T) {
+		for _, salary := range []string{`3000.01`, `"3000.01"`} {
+			var received money.Money
+			createUseCase := &mockCreateProposalUseCase{
+				executeFn: func(ctx context.Context, req *dto.CreateProposalRequest) (*dto.ProposalResponse, error) {
+					received = req.Salary
+					return &dto.ProposalResponse{}, nil
+				},
+			}
+			handler := NewProposalHandler(createUseCase, &mockGetProposalUseCase{}, &mockListProposalsUseCase{})
+
+			req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(`{"salary": `+salary+`}`))
+			rec := httptest.NewRecorder()
+
+			handler.Create(rec, req)
+
+			if rec.Code != http.StatusCreated {
+				t.Errorf("salary %s: expected status 201, got %d", salary, rec.Code)
+			}
+			if received != money.FromCents(300001) {
+				t.Errorf("salary %s: expected 300001 centavos, got %d", salary, received)
+			}
+		}
+	})
+
+	t.Run("should return 400 with a salary field error when the amount has fractions of a centavo", func(t *testing.T) {
+		handler := NewProposalHandler(&mockCreateProposalUseCase{}, &mockGetProposalUseCase{}, &mockListProposalsUseCase{})
+
+		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(`{"salary": "3000.001"}`))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+
+		var errResponse problem
 		json.NewDecoder(rec.Body).Decode(&errResponse)
 
-		if errResponse["code"] != "INVALID_JSON" {
-			t.Errorf("expected code INVALID_JSON, got %q", errResponse["code"])
+		if errResponse.Code != "INVALID_INPUT" {
+			t.Errorf("expected code INVALID_INPUT, got %q", errResponse.Code)
+		}
+		if len(errResponse.Fields) != 1 || errResponse.Fields[0].Pointer != "/salary" || errResponse.Fields[0].Code != "SALARY_INVALID" {
+			t.Errorf("expected a SALARY_INVALID error on /salary, got %+v", errResponse.Fields)
 		}
 	})
 
@@ -134,9 +194,9 @@ func TestProposalHandler_Create(t *testing.T) {
 			},
 		}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
-		reqBody := `{"full_name":"","cpf":"12345678901","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
+		reqBody := `{"full_name":"","cpf":"12345678909","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
 		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(reqBody))
 		rec := httptest.NewRecorder()
 
@@ -147,6 +207,37 @@ func TestProposalHandler_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("should list invalid fields in the 400 response", func(t *testing.T) {
+		createUseCase := &mockCreateProposalUseCase{
+			executeFn: func(ctx context.Context, req *dto.CreateProposalRequest) (*dto.ProposalResponse, error) {
+				return nil, appErrors.NewValidationError([]appErrors.FieldError{
+					{Pointer: "/cpf", Code: "CPF_INVALID_CHECK_DIGIT", Message: "CPF check digits are invalid"},
+					{Pointer: "/address/state", Code: "STATE_INVALID", Message: "state must be one of the 27 UF codes"},
+				})
+			},
+		}
+		handler := NewProposalHandler(createUseCase, &mockGetProposalUseCase{}, &mockListProposalsUseCase{})
+
+		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(`{}`))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+
+		var errResponse problem
+		json.NewDecoder(rec.Body).Decode(&errResponse)
+
+		if errResponse.Code != "INVALID_INPUT" {
+			t.Errorf("expected code INVALID_INPUT, got %q", errResponse.Code)
+		}
+		if len(errResponse.Fields) != 2 || errResponse.Fields[1].Pointer != "/address/state" {
+			t.Errorf("expected cpf and state field errors, got %+v", errResponse.Fields)
+		}
+	})
+
 	t.Run("should return 409 when CPF is duplicated", func(t *testing.T) {
 		createUseCase := &mockCreateProposalUseCase{
 			executeFn: func(ctx context.Context, req *dto.CreateProposalRequest) (*dto.ProposalResponse, error) {
@@ -154,9 +245,9 @@ func TestProposalHandler_Create(t *testing.T) {
 			},
 		}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
-		reqBody := `{"full_name":"John","cpf":"12345678901","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
+		reqBody := `{"full_name":"John","cpf":"12345678909","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
 		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(reqBody))
 		rec := httptest.NewRecorder()
 
@@ -166,11 +257,11 @@ func TestProposalHandler_Create(t *testing.T) {
 			t.Errorf("expected status 409, got %d", rec.Code)
 		}
 
-		var errResponse map[string]string
+		var errResponse problem
 		json.NewDecoder(rec.Body).Decode(&errResponse)
 
-		if errResponse["code"] != "DUPLICATE_CPF" {
-			t.Errorf("expected code DUPLICATE_CPF, got %q", errResponse["code"])
+		if errResponse.Code != "DUPLICATE_CPF" {
+			t.Errorf("expected code DUPLICATE_CPF, got %q", errResponse.Code)
 		}
 	})
 
@@ -181,9 +272,9 @@ func TestProposalHandler_Create(t *testing.T) {
 			},
 		}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
-		reqBody := `{"full_name":"John","cpf":"12345678901","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
+		reqBody := `{"full_name":"John","cpf":"12345678909","email":"j@e.com","phone":"11999999999","birthdate":"15-01-1990","address":{}}`
 		req := httptest.NewRequest(http.MethodPost, "/proposals", bytes.NewBufferString(reqBody))
 		rec := httptest.NewRecorder()
 
@@ -201,7 +292,7 @@ func TestProposalHandler_GetByID(t *testing.T) {
 		expectedResponse := &dto.ProposalResponse{
 			ID:        proposalID,
 			FullName:  "John Doe",
-			CPF:       "12345678901",
+			CPF:       "12345678909",
 			Email:     "john@example.com",
 			Phone:     "11999999999",
 			BirthDate: time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC),
@@ -222,7 +313,7 @@ func TestProposalHandler_GetByID(t *testing.T) {
 				return expectedResponse, nil
 			},
 		}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		req := httptest.NewRequest(http.MethodGet, "/proposals/"+proposalID.String(), nil)
 		rec := httptest.NewRecorder()
@@ -253,7 +344,7 @@ func TestProposalHandler_GetByID(t *testing.T) {
 	t.Run("should return 400 when ID is invalid", func(t *testing.T) {
 		createUseCase := &mockCreateProposalUseCase{}
 		getUseCase := &mockGetProposalUseCase{}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		req := httptest.NewRequest(http.MethodGet, "/proposals/invalid-uuid", nil)
 		rec := httptest.NewRecorder()
@@ -268,11 +359,11 @@ func TestProposalHandler_GetByID(t *testing.T) {
 			t.Errorf("expected status 400, got %d", rec.Code)
 		}
 
-		var errResponse map[string]string
+		var errResponse problem
 		json.NewDecoder(rec.Body).Decode(&errResponse)
 
-		if errResponse["code"] != "INVALID_ID" {
-			t.Errorf("expected code INVALID_ID, got %q", errResponse["code"])
+		if errResponse.Code != "INVALID_ID" {
+			t.Errorf("expected code INVALID_ID, got %q", errResponse.Code)
 		}
 	})
 
@@ -283,7 +374,7 @@ func TestProposalHandler_GetByID(t *testing.T) {
 				return nil, appErrors.NewNotFoundError("proposal")
 			},
 		}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		proposalID := uuid.New()
 		req := httptest.NewRequest(http.MethodGet, "/proposals/"+proposalID.String(), nil)
@@ -299,11 +390,11 @@ func TestProposalHandler_GetByID(t *testing.T) {
 			t.Errorf("expected status 404, got %d", rec.Code)
 		}
 
-		var errResponse map[string]string
+		var errResponse problem
 		json.NewDecoder(rec.Body).Decode(&errResponse)
 
-		if errResponse["code"] != "NOT_FOUND" {
-			t.Errorf("expected code NOT_FOUND, got %q", errResponse["code"])
+		if errResponse.Code != "NOT_FOUND" {
+			t.Errorf("expected code NOT_FOUND, got %q", errResponse.Code)
 		}
 	})
 
@@ -314,7 +405,7 @@ func TestProposalHandler_GetByID(t *testing.T) {
 				return nil, appErrors.NewInternalError("database error", nil)
 			},
 		}
-		handler := NewProposalHandler(createUseCase, getUseCase)
+		handler := NewProposalHandler(createUseCase, getUseCase, &mockListProposalsUseCase{})
 
 		proposalID := uuid.New()
 		req := httptest.NewRequest(http.MethodGet, "/proposals/"+proposalID.String(), nil)
@@ -331,3 +422,73 @@ func TestProposalHandler_GetByID(t *testing.T) {
 		}
 	})
 }
+
+func TestProposalHandler_List(t *testing.T) {
+	t.Run("should pass query filters to use case", func(t *testing.T) {
+		var received *dto.ListProposalsRequest
+		listUseCase := &mockListProposalsUseCase{
+			executeFn: func(ctx context.Context, req *dto.ListProposalsRequest) (*dto.ProposalListResponse, error) {
+				received = req
+				return &dto.ProposalListResponse{NextCursor: "next"}, nil
+			},
+		}
+		handler := NewProposalHandler(&mockCreateProposalUseCase{}, &mockGetProposalUseCase{}, listUseCase)
+
+		req := httptest.NewRequest(http.MethodGet, "/proposals?status=pending&state=SP&cpf_prefix=123&sort=-updated_at&limit=5&cursor=abc", nil)
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+		if received == nil {
+			t.Fatal("expected use case to be called")
+		}
+		if received.Status != "pending" || received.State != "SP" || received.CPFPrefix != "123" {
+			t.Errorf("unexpected filters: %+v", received)
+		}
+		if received.Sort != "-updated_at" || received.Limit != 5 || received.Cursor != "abc" {
+			t.Errorf("unexpected pagination: %+v", received)
+		}
+
+		var response dto.ProposalListResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.NextCursor != "next" {
+			t.Errorf("expected next cursor %q, got %q", "next", response.NextCursor)
+		}
+	})
+
+	t.Run("should return 400 when limit is not a number", func(t *testing.T) {
+		handler := NewProposalHandler(&mockCreateProposalUseCase{}, &mockGetProposalUseCase{}, &mockListProposalsUseCase{})
+
+		req := httptest.NewRequest(http.MethodGet, "/proposals?limit=abc", nil)
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("should return 400 when filters are invalid", func(t *testing.T) {
+		listUseCase := &mockListProposalsUseCase{
+			executeFn: func(ctx context.Context, req *dto.ListProposalsRequest) (*dto.ProposalListResponse, error) {
+				return nil, appErrors.NewInvalidInputError(nil)
+			},
+		}
+		handler := NewProposalHandler(&mockCreateProposalUseCase{}, &mockGetProposalUseCase{}, listUseCase)
+
+		req := httptest.NewRequest(http.MethodGet, "/proposals?status=unknown", nil)
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// OperatorHeader identifies the back-office operator deciding a review.
+const OperatorHeader = "X-Operator-ID"
+
+type reviewDecisionExecutor interface {
+	Approve(ctx context.Context, id uuid.UUID, req *dto.ReviewDecisionRequest) (*dto.ProposalResponse, error)
+	Reject(ctx context.Context, id uuid.UUID, req *dto.ReviewDecisionRequest) (*dto.ProposalResponse, error)
+}
+
+// ReviewHandler serves the back-office manual review queue.
+type ReviewHandler struct {
+	useCase     reviewDecisionExecutor
+	listUseCase listProposalsExecutor
+}
+
+func NewReviewHandler(useCase reviewDecisionExecutor, listUseCase listProposalsExecutor) *ReviewHandler {
+	return &ReviewHandler{useCase: useCase, listUseCase: listUseCase}
+}
+
+// List returns the proposals under review, oldest first.
+func (h *ReviewHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &dto.ListProposalsRequest{
+		Status: string(entities.StatusUnderReview),
+		Sort:   "created_at",
+		Cursor: query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "INVALID_LIMIT", "limit must be a number")
+			return
+		}
+		req.Limit = value
+	}
+
+	response, err := h.listUseCase.Execute(r.Context(), req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ReviewHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.useCase.Approve)
+}
+
+func (h *ReviewHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.useCase.Reject)
+}
+
+func (h *ReviewHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(ctx context.Context, id uuid.UUID, req *dto.ReviewDecisionRequest) (*dto.ProposalResponse, error),
+) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid proposal ID")
+		return
+	}
+
+	var req dto.ReviewDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+	req.Operator = r.Header.Get(OperatorHeader)
+
+	response, err := decide(r.Context(), id, &req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
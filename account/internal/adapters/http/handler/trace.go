@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+const (
+	TraceIDHeader     = "X-Trace-Id"
+	TraceParentHeader = "traceparent"
+)
+
+type traceContextKey struct{}
+
+// requestTrace identifies a request in error responses and logs.
+type requestTrace struct {
+	id     string
+	logger ports.Logger
+}
+
+// Trace assigns a trace id to every request, echoes it in the X-Trace-Id
+// header and makes it available to error responses. The id is taken from a
+// W3C traceparent header when present, so it matches the caller's trace.
+// Panics are logged and answered with a problem response.
+func Trace(logger ports.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			trace := &requestTrace{id: traceIDFrom(r), logger: logger}
+			r = r.WithContext(context.WithValue(r.Context(), traceContextKey{}, trace))
+			w.Header().Set(TraceIDHeader, trace.id)
+
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if recovered == http.ErrAbortHandler {
+						panic(recovered)
+					}
+					logger.Error(r.Context(), "request panicked", "trace_id", trace.id, "method", r.Method, "path", r.URL.Path, "panic", recovered)
+					writeProblem(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "unexpected error")
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func traceFrom(ctx context.Context) *requestTrace {
+	if trace, ok := ctx.Value(traceContextKey{}).(*requestTrace); ok {
+		return trace
+	}
+	return &requestTrace{}
+}
+
+// traceIDFrom reads the trace id of a "version-traceid-parentid-flags"
+// traceparent header, or generates a new one in the same format.
+func traceIDFrom(r *http.Request) string {
+	parts := strings.Split(r.Header.Get(TraceParentHeader), "-")
+	if len(parts) == 4 && isTraceID(parts[1]) {
+		return parts[1]
+	}
+	return newTraceID()
+}
+
+func isTraceID(id string) bool {
+	if len(id) != 32 || id == strings.Repeat("0", 32) {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil && strings.ToLower(id) == id
+}
+
+func newTraceID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type webhookManager interface {
+	Subscribe(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.WebhookSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context) (*dto.WebhookSubscriptionListResponse, error)
+	ListDeliveries(ctx context.Context, req *dto.ListWebhookDeliveriesRequest) (*dto.WebhookDeliveryListResponse, error)
+	Replay(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+}
+
+type WebhookHandler struct {
+	webhooks webhookManager
+}
+
+func NewWebhookHandler(webhooks webhookManager) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	response, err := h.webhooks.Subscribe(r.Context(), &req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	response, err := h.webhooks.ListSubscriptions(r.Context())
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &dto.ListWebhookDeliveriesRequest{Status: query.Get("status")}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "INVALID_LIMIT", "limit must be a number")
+			return
+		}
+		req.Limit = value
+	}
+
+	response, err := h.webhooks.ListDeliveries(r.Context(), req)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *WebhookHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "INVALID_ID", "invalid delivery ID")
+		return
+	}
+
+	response, err := h.webhooks.Replay(r.Context(), id)
+	if err != nil {
+		handleApplicationError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, response)
+}
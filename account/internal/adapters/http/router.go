@@ -1,19 +1,62 @@
 package http
 
 import (
+	"net/http"
+
 	"github.com/gabrielaraujr/golang-case/account/internal/adapters/http/handler"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func NewRouter(proposalHandler *handler.ProposalHandler) *chi.Mux {
+type Handlers struct {
+	Proposal    *handler.ProposalHandler
+	History     *handler.HistoryHandler
+	Events      *handler.EventStreamHandler
+	Account     *handler.AccountHandler
+	Offer       *handler.OfferHandler
+	Cancel      *handler.CancelHandler
+	Document    *handler.DocumentHandler
+	Review      *handler.ReviewHandler
+	Outbox      *handler.OutboxHandler
+	Webhook     *handler.WebhookHandler
+	Idempotency func(http.Handler) http.Handler
+	Trace       func(http.Handler) http.Handler
+}
+
+func NewRouter(h Handlers) *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
+	r.Use(h.Trace)
+	r.NotFound(handler.NotFound)
+	r.MethodNotAllowed(handler.MethodNotAllowed)
 
 	r.Route("/proposals", func(r chi.Router) {
-		r.Post("/", proposalHandler.Create)
-		r.Get("/{id}", proposalHandler.GetByID)
+		r.With(h.Idempotency).Post("/", h.Proposal.Create)
+		r.Get("/", h.Proposal.List)
+		r.Get("/{id}", h.Proposal.GetByID)
+		r.Get("/{id}/history", h.History.GetByProposalID)
+		r.Get("/{id}/events", h.Events.Stream)
+		r.Get("/{id}/account", h.Account.GetByProposalID)
+		r.Post("/{id}/offer/accept", h.Offer.Accept)
+		r.Post("/{id}/offer/decline", h.Offer.Decline)
+		r.Post("/{id}/cancel", h.Cancel.Cancel)
+		r.Post("/{id}/documents", h.Document.Upload)
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", h.Webhook.Create)
+		r.Get("/", h.Webhook.List)
+		r.Get("/deliveries", h.Webhook.ListDeliveries)
+		r.Post("/deliveries/{id}/replay", h.Webhook.ReplayDelivery)
+	})
+
+	r.Route("/backoffice/reviews", func(r chi.Router) {
+		r.Get("/", h.Review.List)
+		r.Post("/{id}/approve", h.Review.Approve)
+		r.Post("/{id}/reject", h.Review.Reject)
 	})
 
+	r.Get("/outbox/stats", h.Outbox.Stats)
+
 	return r
 }
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CardResponse struct {
+	ID          uuid.UUID `json:"id"`
+	MaskedPAN   string    `json:"masked_pan"`
+	ExpiryMonth int       `json:"expiry_month"`
+	ExpiryYear  int       `json:"expiry_year"`
+	Status      string    `json:"status"`
+}
+
+type AccountResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ProposalID uuid.UUID       `json:"proposal_id"`
+	Branch     string          `json:"branch"`
+	Number     string          `json:"number"`
+	CheckDigit string          `json:"check_digit"`
+	Formatted  string          `json:"formatted"`
+	Cards      []*CardResponse `json:"cards"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
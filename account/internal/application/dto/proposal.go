@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
 	"github.com/google/uuid"
 )
 
@@ -16,7 +17,7 @@ type AddressRequest struct {
 type CreateProposalRequest struct {
 	FullName  string         `json:"full_name"`
 	CPF       string         `json:"cpf"`
-	Salary    float64        `json:"salary"`
+	Salary    money.Money    `json:"salary"`
 	Email     string         `json:"email"`
 	Phone     string         `json:"phone"`
 	BirthDate string         `json:"birthdate"`
@@ -24,17 +25,61 @@ type CreateProposalRequest struct {
 }
 
 type ProposalResponse struct {
-	ID        uuid.UUID       `json:"id"`
-	FullName  string          `json:"full_name"`
-	CPF       string          `json:"cpf"`
-	Salary    float64         `json:"salary"`
-	Email     string          `json:"email"`
-	Phone     string          `json:"phone"`
-	BirthDate time.Time       `json:"birthdate"`
-	Address   AddressResponse `json:"address"`
-	Status    string          `json:"status"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
+	ID                 uuid.UUID          `json:"id"`
+	FullName           string             `json:"full_name"`
+	CPF                string             `json:"cpf"`
+	Salary             money.Money        `json:"salary"`
+	Email              string             `json:"email"`
+	Phone              string             `json:"phone"`
+	BirthDate          time.Time          `json:"birthdate"`
+	Address            AddressResponse    `json:"address"`
+	Status             string             `json:"status"`
+	Rejection          *RejectionResponse `json:"rejection,omitempty"`
+	Review             *ReviewResponse    `json:"review,omitempty"`
+	Offer              *OfferResponse     `json:"offer,omitempty"`
+	CancellationReason string             `json:"cancellation_reason,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+type RejectionResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ReviewResponse is why risk-analysis sent the proposal to manual review.
+type ReviewResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type OfferResponse struct {
+	CreditLimit  money.Money `json:"credit_limit"`
+	RiskTier     string      `json:"risk_tier"`
+	RiskScore    int         `json:"risk_score"`
+	AnnualFee    money.Money `json:"annual_fee"`
+	TermsVersion string      `json:"terms_version,omitempty"`
+	ExpiresAt    *time.Time  `json:"expires_at,omitempty"`
+}
+
+// OfferDecisionRequest is the customer's answer to an offer. IPAddress,
+// ForwardedFor and UserAgent are filled by the handler and kept as evidence.
+type OfferDecisionRequest struct {
+	TermsVersion string `json:"terms_version"`
+	IPAddress    string `json:"-"`
+	ForwardedFor string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+type CancelProposalRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ReviewDecisionRequest is an operator's decision on a proposal under
+// review. Operator is filled by the handler from the X-Operator-ID header.
+type ReviewDecisionRequest struct {
+	Notes    string `json:"notes"`
+	Operator string `json:"-"`
 }
 
 type AddressResponse struct {
@@ -43,3 +88,62 @@ type AddressResponse struct {
 	State   string `json:"state"`
 	ZipCode string `json:"zip_code"`
 }
+
+type ListProposalsRequest struct {
+	Status      string
+	CreatedFrom string
+	CreatedTo   string
+	State       string
+	CPFPrefix   string
+	Sort        string
+	Cursor      string
+	Limit       int
+}
+
+type ProposalListResponse struct {
+	Items      []*ProposalResponse `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type StatusHistoryItem struct {
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	EventType      string    `json:"event_type"`
+	MessageID      string    `json:"message_id,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+type ProposalHistoryResponse struct {
+	ProposalID uuid.UUID           `json:"proposal_id"`
+	Items      []StatusHistoryItem `json:"items"`
+}
+
+type ProposalEventResponse struct {
+	ProposalID uuid.UUID `json:"proposal_id"`
+	EventType  string    `json:"event_type"`
+	Status     string    `json:"status"`
+	Final      bool      `json:"final"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// UploadDocumentRequest is a file sent as multipart form data.
+type UploadDocumentRequest struct {
+	Type     string
+	FileName string
+	Content  []byte
+}
+
+// DocumentResponse describes an uploaded document. AnalysisRequested is set
+// once the upload completed the required documents and the proposal was
+// sent to risk-analysis.
+type DocumentResponse struct {
+	ID                uuid.UUID `json:"id"`
+	ProposalID        uuid.UUID `json:"proposal_id"`
+	Type              string    `json:"type"`
+	FileName          string    `json:"file_name"`
+	ContentType       string    `json:"content_type"`
+	Size              int64     `json:"size"`
+	SHA256            string    `json:"sha256"`
+	UploadedAt        time.Time `json:"uploaded_at"`
+	AnalysisRequested bool      `json:"analysis_requested"`
+}
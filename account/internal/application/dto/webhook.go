@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateWebhookRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+type WebhookSubscriptionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookSubscriptionListResponse struct {
+	Items []*WebhookSubscriptionResponse `json:"items"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	Status string
+	Limit  int
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	ProposalID     uuid.UUID  `json:"proposal_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Items []*WebhookDeliveryResponse `json:"items"`
+}
+
+// WebhookEvent is the body partners receive on their webhook endpoint.
+type WebhookEvent struct {
+	ID         uuid.UUID        `json:"id"`
+	Type       string           `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       WebhookEventData `json:"data"`
+}
+
+type WebhookEventData struct {
+	ProposalID     uuid.UUID          `json:"proposal_id"`
+	PreviousStatus string             `json:"previous_status"`
+	Status         string             `json:"status"`
+	Rejection      *RejectionResponse `json:"rejection,omitempty"`
+}
@@ -1,12 +1,26 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
+// ApplicationError is an error meant for the API client. Message is shown
+// to the client; Err is the internal cause, which is only logged.
 type ApplicationError struct {
 	Code       string
 	Message    string
 	StatusCode int
 	Err        error
+	Fields     []FieldError
+}
+
+// FieldError describes one invalid field of the request body. Pointer is a
+// JSON pointer (RFC 6901) to the field, such as "/address/zip_code".
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 func (e *ApplicationError) Error() string {
@@ -21,19 +35,45 @@ func (e *ApplicationError) Unwrap() error {
 	return e.Err
 }
 
+// NewInvalidInputError is a 400 whose message is err itself, so err must be
+// safe to show to the client.
 func NewInvalidInputError(err error) *ApplicationError {
+	message := "invalid request data"
+	if err != nil {
+		message = err.Error()
+	}
 	return &ApplicationError{
 		Code:       "INVALID_INPUT",
-		Message:    "invalid request data",
+		Message:    message,
 		StatusCode: 400,
 		Err:        err,
 	}
 }
 
+// NewValidationError is an INVALID_INPUT error listing every invalid field.
+func NewValidationError(fields []FieldError) *ApplicationError {
+	return &ApplicationError{
+		Code:       "INVALID_INPUT",
+		Message:    "invalid request data",
+		StatusCode: 400,
+		Fields:     fields,
+	}
+}
+
 func NewDuplicateCPFError() *ApplicationError {
 	return &ApplicationError{
 		Code:       "DUPLICATE_CPF",
-		Message:    "CPF already registered",
+		Message:    "CPF already has an open proposal",
+		StatusCode: 409,
+	}
+}
+
+// NewReapplicationCooldownError tells a rejected applicant when they may
+// apply again.
+func NewReapplicationCooldownError(availableAt time.Time) *ApplicationError {
+	return &ApplicationError{
+		Code:       "REAPPLICATION_COOLDOWN",
+		Message:    fmt.Sprintf("a new proposal for this CPF is allowed from %s", availableAt.UTC().Format(time.RFC3339)),
 		StatusCode: 409,
 	}
 }
@@ -54,3 +94,11 @@ func NewNotFoundError(resource string) *ApplicationError {
 		StatusCode: 404,
 	}
 }
+
+func NewConflictError(code string, err error) *ApplicationError {
+	return &ApplicationError{
+		Code:       code,
+		Message:    err.Error(),
+		StatusCode: 409,
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+const (
+	DefaultBranch  = "0001"
+	DefaultCardBIN = "539999"
+)
+
+type AccountIssuerConfig struct {
+	Branch  string
+	CardBIN string
+}
+
+// accountIssuer lets the offer acceptance flow open the account inside its
+// own transaction.
+type accountIssuer interface {
+	Issue(ctx context.Context, proposal *entities.Proposal) error
+}
+
+// AccountIssuer opens the customer account and issues its virtual card once
+// the customer accepts the offer.
+type AccountIssuer struct {
+	accounts  ports.AccountRepository
+	cards     ports.CardRepository
+	tokenizer ports.CardTokenizer
+	cfg       AccountIssuerConfig
+}
+
+func NewAccountIssuer(
+	accounts ports.AccountRepository,
+	cards ports.CardRepository,
+	tokenizer ports.CardTokenizer,
+	cfg AccountIssuerConfig,
+) *AccountIssuer {
+	if cfg.Branch == "" {
+		cfg.Branch = DefaultBranch
+	}
+	if cfg.CardBIN == "" {
+		cfg.CardBIN = DefaultCardBIN
+	}
+
+	return &AccountIssuer{
+		accounts:  accounts,
+		cards:     cards,
+		tokenizer: tokenizer,
+		cfg:       cfg,
+	}
+}
+
+// Issue is idempotent: a proposal that already has an account is left as is,
+// so a retried acceptance doesn't open a second one.
+func (i *AccountIssuer) Issue(ctx context.Context, proposal *entities.Proposal) error {
+	_, err := i.accounts.FindByProposalID(ctx, proposal.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, domainErrors.ErrAccountNotFound) {
+		return fmt.Errorf("find account: %w", err)
+	}
+
+	sequence, err := i.accounts.NextAccountNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("reserve account number: %w", err)
+	}
+	account := entities.NewCustomerAccount(proposal.ID, i.cfg.Branch, sequence)
+
+	pan, err := entities.GeneratePAN(i.cfg.CardBIN)
+	if err != nil {
+		return fmt.Errorf("generate pan: %w", err)
+	}
+	token, err := i.tokenizer.Tokenize(ctx, pan)
+	if err != nil {
+		return fmt.Errorf("tokenize pan: %w", err)
+	}
+	card := entities.NewVirtualCard(account, pan, token)
+
+	if err := i.accounts.Save(ctx, account); err != nil {
+		return fmt.Errorf("save account: %w", err)
+	}
+	if err := i.cards.Save(ctx, card); err != nil {
+		return fmt.Errorf("save card: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+type mockAccountRepository struct {
+	accounts []*entities.CustomerAccount
+	sequence int64
+}
+
+func (m *mockAccountRepository) NextAccountNumber(ctx context.Context) (int64, error) {
+	m.sequence++
+	return m.sequence, nil
+}
+
+func (m *mockAccountRepository) Save(ctx context.Context, account *entities.CustomerAccount) error {
+	m.accounts = append(m.accounts, account)
+	return nil
+}
+
+func (m *mockAccountRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) (*entities.CustomerAccount, error) {
+	for _, account := range m.accounts {
+		if account.ProposalID == proposalID {
+			return account, nil
+		}
+	}
+	return nil, domainErrors.ErrAccountNotFound
+}
+
+type mockCardRepository struct {
+	cards []*entities.Card
+}
+
+func (m *mockCardRepository) Save(ctx context.Context, card *entities.Card) error {
+	m.cards = append(m.cards, card)
+	return nil
+}
+
+func (m *mockCardRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entities.Card, error) {
+	var cards []*entities.Card
+	for _, card := range m.cards {
+		if card.AccountID == accountID {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+type mockCardTokenizer struct {
+	pans []string
+}
+
+func (m *mockCardTokenizer) Tokenize(ctx context.Context, pan string) (string, error) {
+	m.pans = append(m.pans, pan)
+	return "tok_test", nil
+}
+
+func TestAccountIssuer_Issue(t *testing.T) {
+	t.Run("should open account and issue masked virtual card", func(t *testing.T) {
+		accounts := &mockAccountRepository{}
+		cards := &mockCardRepository{}
+		tokenizer := &mockCardTokenizer{}
+		issuer := NewAccountIssuer(accounts, cards, tokenizer, AccountIssuerConfig{})
+		proposal := newProposalWithStatus(entities.StatusAccepted)
+
+		err := issuer.Issue(context.Background(), proposal)
+
+		assertNoError(t, err)
+		if len(accounts.accounts) != 1 || len(cards.cards) != 1 {
+			t.Fatalf("expected 1 account and 1 card, got %d and %d", len(accounts.accounts), len(cards.cards))
+		}
+		account := accounts.accounts[0]
+		if account.ProposalID != proposal.ID || account.Branch != DefaultBranch || account.Number != "00000001" {
+			t.Errorf("unexpected account %+v", account)
+		}
+
+		card := cards.cards[0]
+		pan := tokenizer.pans[0]
+		if !entities.IsLuhnValid(pan) {
+			t.Errorf("expected Luhn-valid PAN, got %q", pan)
+		}
+		if strings.Contains(card.MaskedPAN, pan[6:12]) || card.MaskedPAN != entities.MaskPAN(pan) {
+			t.Errorf("expected masked PAN, got %q", card.MaskedPAN)
+		}
+		if card.Token != "tok_test" {
+			t.Errorf("expected tokenized PAN, got %q", card.Token)
+		}
+	})
+
+	t.Run("should not open a second account for the same proposal", func(t *testing.T) {
+		accounts := &mockAccountRepository{}
+		cards := &mockCardRepository{}
+		issuer := NewAccountIssuer(accounts, cards, &mockCardTokenizer{}, AccountIssuerConfig{})
+		proposal := newProposalWithStatus(entities.StatusAccepted)
+
+		assertNoError(t, issuer.Issue(context.Background(), proposal))
+		assertNoError(t, issuer.Issue(context.Background(), proposal))
+
+		if len(accounts.accounts) != 1 || len(cards.cards) != 1 {
+			t.Errorf("expected 1 account and 1 card, got %d and %d", len(accounts.accounts), len(cards.cards))
+		}
+	})
+}
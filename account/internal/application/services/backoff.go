@@ -0,0 +1,13 @@
+package services
+
+import "time"
+
+// exponentialBackoff doubles base for every attempt after the first, capped
+// at max.
+func exponentialBackoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 20, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := exponentialBackoff(time.Second, 10*time.Second, tt.attempts); got != tt.want {
+			t.Errorf("exponentialBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+// CancelProposalUseCase withdraws a proposal at the customer's request.
+// Risk events that arrive afterwards are ignored by the event handler.
+type CancelProposalUseCase struct {
+	repository  ports.ProposalRepository
+	transitions *ProposalTransitioner
+	logger      ports.Logger
+}
+
+func NewCancelProposalUseCase(
+	repo ports.ProposalRepository,
+	transitions *ProposalTransitioner,
+	logger ports.Logger,
+) *CancelProposalUseCase {
+	return &CancelProposalUseCase{
+		repository:  repo,
+		transitions: transitions,
+		logger:      logger,
+	}
+}
+
+func (uc *CancelProposalUseCase) Execute(
+	ctx context.Context,
+	id uuid.UUID,
+	req *dto.CancelProposalRequest,
+) (*dto.ProposalResponse, error) {
+	proposal, err := uc.repository.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+
+	cancel := func() error { return proposal.Cancel(req.Reason) }
+	err = uc.transitions.Transition(ctx, proposal, events.EventProposalCancelled, "", cancel)
+	switch {
+	case errors.Is(err, domainErrors.ErrCancellationReasonRequired):
+		return nil, appErrors.NewInvalidInputError(err)
+	case errors.Is(err, domainErrors.ErrOnlyOpenProposalsCanBeCancelled):
+		return nil, appErrors.NewConflictError("PROPOSAL_NOT_CANCELLABLE", err)
+	case errors.Is(err, domainErrors.ErrProposalVersionConflict):
+		return nil, appErrors.NewConflictError("PROPOSAL_VERSION_CONFLICT", err)
+	case err != nil:
+		return nil, appErrors.NewInternalError("failed to cancel proposal", err)
+	}
+
+	uc.logger.Info(ctx, "proposal cancelled", "proposal_id", proposal.ID)
+	return entityToResponse(proposal), nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+func TestCancelProposalUseCase(t *testing.T) {
+	setup := func(proposal *entities.Proposal) (*CancelProposalUseCase, *mockRepository, *mockStatusHistoryRepository) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				if proposal == nil {
+					return nil, domainErrors.ErrProposalNotFound
+				}
+				return proposal, nil
+			},
+		}
+		history := &mockStatusHistoryRepository{}
+		transitions := NewProposalTransitioner(repo, history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{})
+		return NewCancelProposalUseCase(repo, transitions, &mockLogger{}), repo, history
+	}
+	request := &dto.CancelProposalRequest{Reason: "found a better offer"}
+
+	for _, status := range []entities.ProposalStatus{entities.StatusPending, entities.StatusAnalyzing, entities.StatusOfferPending} {
+		t.Run("should cancel "+string(status)+" proposal", func(t *testing.T) {
+			proposal := newProposalWithStatus(status)
+			uc, repo, history := setup(proposal)
+
+			response, err := uc.Execute(context.Background(), proposal.ID, request)
+
+			assertNoError(t, err)
+			if response.Status != string(entities.StatusCancelled) || response.CancellationReason != request.Reason {
+				t.Errorf("expected cancelled with reason, got %q %q", response.Status, response.CancellationReason)
+			}
+			if len(repo.updated) != 1 || len(history.saved) != 1 {
+				t.Errorf("expected update and history entry, got %d and %d", len(repo.updated), len(history.saved))
+			}
+			if history.saved[0].EventType != domainErrors.EventProposalCancelled {
+				t.Errorf("expected history event %q, got %q", domainErrors.EventProposalCancelled, history.saved[0].EventType)
+			}
+		})
+	}
+
+	t.Run("should return conflict when proposal is finalized", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusRejected)
+		uc, repo, _ := setup(proposal)
+
+		_, err := uc.Execute(context.Background(), proposal.ID, request)
+
+		assertApplicationError(t, err, "PROPOSAL_NOT_CANCELLABLE", 409)
+		if len(repo.updated) != 0 {
+			t.Error("expected no update")
+		}
+	})
+
+	t.Run("should return conflict when the proposal changed concurrently", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		uc, repo, _ := setup(proposal)
+		repo.updateFn = func(ctx context.Context, p *entities.Proposal) error {
+			return domainErrors.ErrProposalVersionConflict
+		}
+
+		_, err := uc.Execute(context.Background(), proposal.ID, request)
+
+		assertApplicationError(t, err, "PROPOSAL_VERSION_CONFLICT", 409)
+	})
+
+	t.Run("should require a reason", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		uc, _, _ := setup(proposal)
+
+		_, err := uc.Execute(context.Background(), proposal.ID, &dto.CancelProposalRequest{Reason: "  "})
+
+		assertApplicationError(t, err, "INVALID_INPUT", 400)
+	})
+
+	t.Run("should return not found when proposal does not exist", func(t *testing.T) {
+		uc, _, _ := setup(nil)
+
+		_, err := uc.Execute(context.Background(), uuid.New(), request)
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+}
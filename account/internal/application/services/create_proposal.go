@@ -2,32 +2,115 @@ package services
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	errors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
 	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
 	"github.com/gabrielaraujr/golang-case/account/internal/ports"
 )
 
+// CreateProposalUseCase saves a new pending proposal and requests its
+// analysis, without documents, in the same transaction. With AwaitDocuments,
+// the analysis is only requested once the documents are uploaded, see
+// UploadDocumentUseCase.
 type CreateProposalUseCase struct {
-	repository ports.ProposalRepository
-	producer   ports.QueueProducer
-	logger     ports.Logger
+	repository     ports.ProposalRepository
+	publisher      domainEventPublisher
+	txManager      ports.TransactionManager
+	notifier       statusNotifier
+	logger         ports.Logger
+	agePolicy      entities.AgePolicy
+	reapply        entities.ReapplicationPolicy
+	awaitDocuments bool
 }
 
-const DateLayoutBR = "02-01-2006" // Brazilian format (dd-mm-yyyy)
+// DefaultReapplicationCooldown is how long a rejected CPF waits before
+// applying again.
+const DefaultReapplicationCooldown = 30 * 24 * time.Hour
+
+// CreateProposalConfig holds the eligibility rules for new proposals.
+// MaxApplicantAge is optional; zero accepts any age from 18 up.
+// AwaitDocuments holds the analysis until the required documents are
+// uploaded.
+type CreateProposalConfig struct {
+	MaxApplicantAge       int
+	ReapplicationCooldown time.Duration
+	AwaitDocuments        bool
+}
+
+const (
+	DateLayoutBR  = "02-01-2006"  // Brazilian format (dd-mm-yyyy)
+	DateLayoutISO = time.DateOnly // ISO 8601 calendar date (yyyy-mm-dd)
+)
+
+// birthDateLayouts are the accepted formats for the request birthdate.
+var birthDateLayouts = []string{DateLayoutBR, DateLayoutISO}
+
+// validationErrorCodes gives each domain validation error its own code in
+// the fields of an INVALID_INPUT response. Errors not listed here are
+// reported as INVALID_VALUE.
+var validationErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{err: domainErrors.ErrFullNameRequired, code: "FULL_NAME_REQUIRED"},
+	{err: domainErrors.ErrCPFRequired, code: "CPF_REQUIRED"},
+	{err: domainErrors.ErrCPFInvalidFormat, code: "CPF_INVALID_FORMAT"},
+	{err: domainErrors.ErrCPFInvalidLength, code: "CPF_INVALID_LENGTH"},
+	{err: domainErrors.ErrCPFRepeatedDigits, code: "CPF_REPEATED_DIGITS"},
+	{err: domainErrors.ErrCPFInvalidCheckDigits, code: "CPF_INVALID_CHECK_DIGIT"},
+	{err: domainErrors.ErrSalaryRequired, code: "SALARY_REQUIRED"},
+	{err: domainErrors.ErrEmailRequired, code: "EMAIL_REQUIRED"},
+	{err: domainErrors.ErrEmailInvalid, code: "EMAIL_INVALID"},
+	{err: domainErrors.ErrPhoneInvalidDDD, code: "PHONE_INVALID_DDD"},
+	{err: domainErrors.ErrPhoneInvalidNumber, code: "PHONE_INVALID_NUMBER"},
+	{err: domainErrors.ErrBirthDateRequired, code: "BIRTH_DATE_REQUIRED"},
+	{err: domainErrors.ErrBirthDateInFuture, code: "BIRTH_DATE_IN_FUTURE"},
+	{err: domainErrors.ErrApplicantUnderage, code: "APPLICANT_UNDERAGE"},
+	{err: domainErrors.ErrApplicantAboveMaxAge, code: "APPLICANT_ABOVE_MAX_AGE"},
+	{err: domainErrors.ErrZipCodeRequired, code: "ZIP_CODE_REQUIRED"},
+	{err: domainErrors.ErrZipCodeInvalid, code: "ZIP_CODE_INVALID"},
+	{err: domainErrors.ErrStateRequired, code: "STATE_REQUIRED"},
+	{err: domainErrors.ErrStateInvalid, code: "STATE_INVALID"},
+}
+
+// fieldPointers maps proposal fields to their place in CreateProposalRequest.
+var fieldPointers = map[string]string{
+	entities.FieldFullName:       "/full_name",
+	entities.FieldCPF:            "/cpf",
+	entities.FieldSalary:         "/salary",
+	entities.FieldEmail:          "/email",
+	entities.FieldPhone:          "/phone",
+	entities.FieldBirthDate:      "/birthdate",
+	entities.FieldAddressState:   "/address/state",
+	entities.FieldAddressZipCode: "/address/zip_code",
+}
 
 func NewCreateProposalUseCase(
 	repo ports.ProposalRepository,
-	prod ports.QueueProducer,
+	publisher domainEventPublisher,
+	txManager ports.TransactionManager,
+	notifier statusNotifier,
 	logger ports.Logger,
+	cfg CreateProposalConfig,
 ) *CreateProposalUseCase {
+	if cfg.ReapplicationCooldown == 0 {
+		cfg.ReapplicationCooldown = DefaultReapplicationCooldown
+	}
+
 	return &CreateProposalUseCase{
-		repository: repo,
-		producer:   prod,
-		logger:     logger,
+		repository:     repo,
+		publisher:      publisher,
+		txManager:      txManager,
+		notifier:       notifier,
+		logger:         logger,
+		agePolicy:      entities.AgePolicy{MaxAge: cfg.MaxApplicantAge},
+		reapply:        entities.ReapplicationPolicy{Cooldown: cfg.ReapplicationCooldown},
+		awaitDocuments: cfg.AwaitDocuments,
 	}
 }
 
@@ -37,9 +120,16 @@ func (uc *CreateProposalUseCase) Execute(
 ) (*dto.ProposalResponse, error) {
 	uc.logger.Info(ctx, "creating proposal", "cpf", req.CPF)
 
-	birthDate, err := time.Parse(DateLayoutBR, req.BirthDate)
-	if err != nil {
-		return nil, errors.NewInvalidInputError(err)
+	// A malformed birth date is reported along with the other fields.
+	// NewProposal then gets a zero date, whose error is left out.
+	var fields []appErrors.FieldError
+	birthDate, ok := parseBirthDate(req.BirthDate)
+	if !ok {
+		fields = append(fields, appErrors.FieldError{
+			Pointer: fieldPointers[entities.FieldBirthDate],
+			Code:    "BIRTH_DATE_INVALID_FORMAT",
+			Message: "birth date must be in dd-mm-yyyy or yyyy-mm-dd format",
+		})
 	}
 
 	address := entities.Address{
@@ -57,38 +147,138 @@ func (uc *CreateProposalUseCase) Execute(
 		req.Phone,
 		birthDate,
 		address,
+		uc.agePolicy,
 	)
 	if err != nil {
-		return nil, errors.NewInvalidInputError(err)
+		return nil, newValidationError(err, fields)
+	}
+	if len(fields) > 0 {
+		return nil, appErrors.NewValidationError(fields)
 	}
 
-	existing, _ := uc.repository.FindByCPF(ctx, req.CPF)
-	if existing != nil {
-		return nil, errors.NewDuplicateCPFError()
+	if err := uc.checkReapplication(ctx, proposal.CPF); err != nil {
+		return nil, err
 	}
 
-	if err := uc.repository.Save(ctx, proposal); err != nil {
+	// The event is relayed to the queue by OutboxRelay once the transaction commits.
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.Save(ctx, proposal); err != nil {
+			return err
+		}
+		if uc.awaitDocuments {
+			return nil
+		}
+		return uc.publisher.Publish(ctx, newProposalCreatedEvent(proposal, nil))
+	})
+	// The open proposal check above can race with a concurrent request; the
+	// unique index on open proposals settles it.
+	if errors.Is(err, domainErrors.ErrProposalAlreadyOpen) {
+		return nil, appErrors.NewDuplicateCPFError()
+	}
+	if err != nil {
 		uc.logger.Error(ctx, "failed to save proposal", "error", err)
-		return nil, errors.NewInternalError("failed to save proposal", err)
+		return nil, appErrors.NewInternalError("failed to save proposal", err)
 	}
 
-	event := &events.ProposalCreatedEvent{
+	uc.notifier.NotifyStatus(ctx, proposal)
+
+	uc.logger.Info(ctx, "proposal created", "proposal_id", proposal.ID)
+	return entityToResponse(proposal), nil
+}
+
+// newProposalCreatedEvent asks risk-analysis to analyze the proposal, with
+// the metadata of the documents uploaded so far.
+func newProposalCreatedEvent(
+	proposal *entities.Proposal,
+	documents []events.DocumentMetadata,
+) *events.ProposalCreatedEvent {
+	return &events.ProposalCreatedEvent{
 		EventType:  events.EventProposalCreated,
 		ProposalID: proposal.ID,
 		Payload: &events.ProposalPayload{
-			FullName: proposal.FullName,
-			CPF:      proposal.CPF,
-			Salary:   proposal.Salary,
+			FullName:    proposal.FullName,
+			CPF:         proposal.CPF,
+			Salary:      proposal.Salary,
+			SalaryCents: proposal.Salary.Cents(),
+			BirthDate:   proposal.BirthDate.Format(DateLayoutISO),
+			Documents:   documents,
 		},
 	}
-	_ = uc.producer.Publish(ctx, event) // Fire and forget
+}
 
-	uc.logger.Info(ctx, "proposal created", "proposal_id", proposal.ID)
-	return entityToResponse(proposal), nil
+// checkReapplication applies the reapplication policy against the latest
+// proposal of the CPF. Earlier proposals are kept as its history.
+func (uc *CreateProposalUseCase) checkReapplication(ctx context.Context, cpf string) error {
+	latest, err := uc.repository.FindLatestByCPF(ctx, cpf)
+	if errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil
+	}
+	if err != nil {
+		uc.logger.Error(ctx, "failed to find proposals by CPF", "error", err)
+		return appErrors.NewInternalError("failed to check previous proposals", err)
+	}
+
+	switch err := uc.reapply.Check(latest, time.Now()); {
+	case errors.Is(err, domainErrors.ErrProposalAlreadyOpen):
+		return appErrors.NewDuplicateCPFError()
+	case errors.Is(err, domainErrors.ErrReapplicationCooldown):
+		return appErrors.NewReapplicationCooldownError(uc.reapply.AvailableAt(latest))
+	}
+	return nil
+}
+
+// parseBirthDate accepts an empty birth date as the zero time, so the domain
+// reports it as missing rather than malformed.
+func parseBirthDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, true
+	}
+	for _, layout := range birthDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// newValidationError turns the violations returned by NewProposal into
+// field errors, appended to the ones already found in the request. A field
+// already reported keeps its first error.
+func newValidationError(err error, fields []appErrors.FieldError) *appErrors.ApplicationError {
+	var violations domainErrors.ValidationErrors
+	if !errors.As(err, &violations) {
+		return appErrors.NewInvalidInputError(err)
+	}
+
+	reported := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		reported[field.Pointer] = true
+	}
+	for _, violation := range violations {
+		pointer := fieldPointers[violation.Field]
+		if reported[pointer] {
+			continue
+		}
+		fields = append(fields, appErrors.FieldError{
+			Pointer: pointer,
+			Code:    validationErrorCode(violation.Err),
+			Message: violation.Err.Error(),
+		})
+	}
+	return appErrors.NewValidationError(fields)
+}
+
+func validationErrorCode(err error) string {
+	for _, v := range validationErrorCodes {
+		if errors.Is(err, v.err) {
+			return v.code
+		}
+	}
+	return "INVALID_VALUE"
 }
 
 func entityToResponse(p *entities.Proposal) *dto.ProposalResponse {
-	return &dto.ProposalResponse{
+	response := &dto.ProposalResponse{
 		ID:        p.ID,
 		FullName:  p.FullName,
 		CPF:       p.CPF,
@@ -102,8 +292,34 @@ func entityToResponse(p *entities.Proposal) *dto.ProposalResponse {
 			State:   p.Address.State,
 			ZipCode: p.Address.ZipCode,
 		},
-		Status:    string(p.Status),
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		Status:             string(p.Status),
+		CancellationReason: p.CancellationReason,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+	if p.Rejection != nil {
+		response.Rejection = &dto.RejectionResponse{
+			Code:    p.Rejection.Code,
+			Message: p.Rejection.Message,
+		}
+	}
+	if p.Review != nil {
+		response.Review = &dto.ReviewResponse{
+			Code:    p.Review.Code,
+			Message: p.Review.Message,
+		}
+	}
+	if p.Offer != nil {
+		response.Offer = &dto.OfferResponse{
+			CreditLimit:  p.Offer.CreditLimit,
+			RiskTier:     p.Offer.RiskTier,
+			RiskScore:    p.Offer.RiskScore,
+			AnnualFee:    p.Offer.AnnualFee,
+			TermsVersion: p.Offer.TermsVersion,
+		}
+		if !p.Offer.ExpiresAt.IsZero() {
+			response.Offer.ExpiresAt = &p.Offer.ExpiresAt
+		}
 	}
+	return response
 }
@@ -1,23 +1,28 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
 	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
 	"github.com/google/uuid"
 )
 
 func TestCreateProposalUseCase_Execute(t *testing.T) {
 	t.Run("should create proposal successfully", func(t *testing.T) {
 		repo := &mockRepository{}
-		producer := &mockQueueProducer{}
 		logger := &mockLogger{}
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, logger, CreateProposalConfig{})
 		req := newRequestBuilder().build()
 
 		response, err := useCase.Execute(context.Background(), req)
@@ -39,37 +44,61 @@ func TestCreateProposalUseCase_Execute(t *testing.T) {
 
 	t.Run("should return error for invalid birth date format", func(t *testing.T) {
 		repo := &mockRepository{}
-		producer := &mockQueueProducer{}
 		logger := &mockLogger{}
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
-		req := newRequestBuilder().withBirthDate("1990-01-15").build()
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, logger, CreateProposalConfig{})
+		req := newRequestBuilder().withBirthDate("15/01/1990").build()
 
 		response, err := useCase.Execute(context.Background(), req)
 
 		assertError(t, err)
 		assertApplicationError(t, err, "INVALID_INPUT", 400)
+		assertFields(t, err, appErrors.FieldError{Pointer: "/birthdate", Code: "BIRTH_DATE_INVALID_FORMAT"})
 		if response != nil {
 			t.Error("expected nil response")
 		}
 	})
 
+	t.Run("should report every invalid field at once", func(t *testing.T) {
+		useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+		req := newRequestBuilder().
+			withCPF("12345678901").
+			withEmail("john@").
+			withPhone("20987654321").
+			withBirthDate("15/01/1990").
+			withAddress(dto.AddressRequest{Street: "123 Main St", City: "São Paulo", State: "XX"}).
+			build()
+		req.FullName = ""
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		assertApplicationError(t, err, "INVALID_INPUT", 400)
+		assertFields(t, err,
+			appErrors.FieldError{Pointer: "/birthdate", Code: "BIRTH_DATE_INVALID_FORMAT"},
+			appErrors.FieldError{Pointer: "/full_name", Code: "FULL_NAME_REQUIRED"},
+			appErrors.FieldError{Pointer: "/cpf", Code: "CPF_INVALID_CHECK_DIGIT"},
+			appErrors.FieldError{Pointer: "/email", Code: "EMAIL_INVALID"},
+			appErrors.FieldError{Pointer: "/phone", Code: "PHONE_INVALID_DDD"},
+			appErrors.FieldError{Pointer: "/address/state", Code: "STATE_INVALID"},
+			appErrors.FieldError{Pointer: "/address/zip_code", Code: "ZIP_CODE_REQUIRED"},
+		)
+	})
+
 	t.Run("should return error when CPF already exists", func(t *testing.T) {
 		existingProposal := &entities.Proposal{
 			ID:  uuid.New(),
-			CPF: "12345678901",
+			CPF: "12345678909",
 		}
 
 		repo := &mockRepository{
-			findByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
+			findLatestByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
 				return existingProposal, nil
 			},
 		}
-		producer := &mockQueueProducer{}
 		logger := &mockLogger{}
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
-		req := newRequestBuilder().withCPF("12345678901").build()
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, logger, CreateProposalConfig{})
+		req := newRequestBuilder().withCPF("12345678909").build()
 
 		response, err := useCase.Execute(context.Background(), req)
 
@@ -80,16 +109,151 @@ func TestCreateProposalUseCase_Execute(t *testing.T) {
 		}
 	})
 
+	t.Run("should apply the reapplication cooldown after a rejection", func(t *testing.T) {
+		cfg := CreateProposalConfig{ReapplicationCooldown: 72 * time.Hour}
+		tests := []struct {
+			name       string
+			rejectedAt time.Time
+			wantCode   string
+		}{
+			{name: "within cooldown", rejectedAt: time.Now().Add(-71 * time.Hour), wantCode: "REAPPLICATION_COOLDOWN"},
+			{name: "after cooldown", rejectedAt: time.Now().Add(-73 * time.Hour)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rejected := &entities.Proposal{ID: uuid.New(), CPF: "12345678909", Status: entities.StatusRejected, UpdatedAt: tt.rejectedAt}
+				repo := &mockRepository{
+					findLatestByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
+						return rejected, nil
+					},
+				}
+				useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, cfg)
+
+				response, err := useCase.Execute(context.Background(), newRequestBuilder().build())
+
+				if tt.wantCode == "" {
+					assertNoError(t, err)
+					if response.ID == rejected.ID {
+						t.Error("expected a new proposal, not the rejected one")
+					}
+					return
+				}
+				assertApplicationError(t, err, tt.wantCode, 409)
+			})
+		}
+	})
+
+	t.Run("should allow a new proposal after the offer was declined", func(t *testing.T) {
+		repo := &mockRepository{
+			findLatestByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
+				return &entities.Proposal{ID: uuid.New(), Status: entities.StatusDeclined, UpdatedAt: time.Now()}, nil
+			},
+		}
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+		_, err := useCase.Execute(context.Background(), newRequestBuilder().build())
+
+		assertNoError(t, err)
+	})
+
+	t.Run("should return DUPLICATE_CPF when a concurrent request opened a proposal first", func(t *testing.T) {
+		repo := &mockRepository{
+			saveFn: func(ctx context.Context, p *entities.Proposal) error {
+				return events.ErrProposalAlreadyOpen
+			},
+		}
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+		_, err := useCase.Execute(context.Background(), newRequestBuilder().build())
+
+		assertApplicationError(t, err, "DUPLICATE_CPF", 409)
+	})
+
+	t.Run("should return internal error when previous proposals cannot be read", func(t *testing.T) {
+		repo := &mockRepository{
+			findLatestByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
+				return nil, errors.New("database error")
+			},
+		}
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+		_, err := useCase.Execute(context.Background(), newRequestBuilder().build())
+
+		assertApplicationError(t, err, "INTERNAL_ERROR", 500)
+	})
+
+	t.Run("should return a specific code for each invalid CPF", func(t *testing.T) {
+		tests := map[string]string{
+			"1234567890a":    "CPF_INVALID_FORMAT",
+			"123456789":      "CPF_INVALID_LENGTH",
+			"111.111.111-11": "CPF_REPEATED_DIGITS",
+			"12345678901":    "CPF_INVALID_CHECK_DIGIT",
+		}
+		for cpf, code := range tests {
+			useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+			_, err := useCase.Execute(context.Background(), newRequestBuilder().withCPF(cpf).build())
+
+			assertApplicationError(t, err, "INVALID_INPUT", 400)
+			assertFields(t, err, appErrors.FieldError{Pointer: "/cpf", Code: code})
+		}
+	})
+
+	t.Run("should return a specific code for each invalid contact or address field", func(t *testing.T) {
+		address := func(state, zipCode string) dto.AddressRequest {
+			return dto.AddressRequest{Street: "123 Main St", City: "São Paulo", State: state, ZipCode: zipCode}
+		}
+		tests := []struct {
+			name    string
+			request *requestBuilder
+			pointer string
+			code    string
+		}{
+			{"email", newRequestBuilder().withEmail("john@"), "/email", "EMAIL_INVALID"},
+			{"phone DDD", newRequestBuilder().withPhone("20987654321"), "/phone", "PHONE_INVALID_DDD"},
+			{"phone number", newRequestBuilder().withPhone("1198765"), "/phone", "PHONE_INVALID_NUMBER"},
+			{"zip code", newRequestBuilder().withAddress(address("SP", "0123-456")), "/address/zip_code", "ZIP_CODE_INVALID"},
+			{"state", newRequestBuilder().withAddress(address("XX", "01234-567")), "/address/state", "STATE_INVALID"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+				_, err := useCase.Execute(context.Background(), tt.request.build())
+
+				assertApplicationError(t, err, "INVALID_INPUT", 400)
+				assertFields(t, err, appErrors.FieldError{Pointer: tt.pointer, Code: tt.code})
+			})
+		}
+	})
+
+	t.Run("should normalize formatted CPF before checking duplicates", func(t *testing.T) {
+		var lookedUp string
+		repo := &mockRepository{
+			findLatestByCPFFn: func(ctx context.Context, cpf string) (*entities.Proposal, error) {
+				lookedUp = cpf
+				return nil, nil
+			},
+		}
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+		response, err := useCase.Execute(context.Background(), newRequestBuilder().withCPF("123.456.789-09").build())
+
+		assertNoError(t, err)
+		if lookedUp != "12345678909" || response.CPF != "12345678909" {
+			t.Errorf("expected normalized CPF, looked up %q and returned %q", lookedUp, response.CPF)
+		}
+	})
+
 	t.Run("should return error when repository save fails", func(t *testing.T) {
 		repo := &mockRepository{
 			saveFn: func(ctx context.Context, p *entities.Proposal) error {
 				return errors.New("database error")
 			},
 		}
-		producer := &mockQueueProducer{}
 		logger := &mockLogger{}
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, logger, CreateProposalConfig{})
 		req := newRequestBuilder().build()
 
 		response, err := useCase.Execute(context.Background(), req)
@@ -101,52 +265,130 @@ func TestCreateProposalUseCase_Execute(t *testing.T) {
 		}
 	})
 
-	t.Run("should publish event after successful creation", func(t *testing.T) {
-		var publishedEvent *events.ProposalCreatedEvent
+	t.Run("should store event in outbox after successful creation", func(t *testing.T) {
+		outbox := &mockOutboxRepository{}
 
-		repo := &mockRepository{}
-		producer := &mockQueueProducer{
-			publishFn: func(ctx context.Context, event *events.ProposalCreatedEvent) error {
-				publishedEvent = event
-				return nil
+		useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(outbox), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+		response, err := useCase.Execute(context.Background(), newRequestBuilder().build())
+
+		assertNoError(t, err)
+		if len(outbox.saved) != 1 {
+			t.Fatalf("expected 1 outbox message, got %d", len(outbox.saved))
+		}
+
+		message := outbox.saved[0]
+		if message.EventType != events.EventProposalCreated {
+			t.Errorf("expected event type %q, got %q", events.EventProposalCreated, message.EventType)
+		}
+		if message.AggregateID != response.ID {
+			t.Error("outbox aggregate ID doesn't match response ID")
+		}
+		if message.Destination != ports.QueueProposals {
+			t.Errorf("expected destination %q, got %q", ports.QueueProposals, message.Destination)
+		}
+
+		var event events.ProposalCreatedEvent
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			t.Fatalf("failed to decode outbox payload: %v", err)
+		}
+		if event.ProposalID != response.ID {
+			t.Error("event proposal ID doesn't match response ID")
+		}
+		if event.Payload.BirthDate != "1990-01-15" {
+			t.Errorf("expected ISO birth date in payload, got %q", event.Payload.BirthDate)
+		}
+		if event.Payload.Salary != money.MustParse("5000") || event.Payload.SalaryCents != 500000 {
+			t.Errorf("expected salary 5000.00 and 500000 centavos in payload, got %s and %d", event.Payload.Salary, event.Payload.SalaryCents)
+		}
+		if !bytes.Contains(message.Payload, []byte(`"salary":5000.00,"salary_cents":500000`)) {
+			t.Errorf("expected salary as a JSON number for existing consumers, got %s", message.Payload)
+		}
+		if len(event.Payload.Documents) != 0 {
+			t.Errorf("expected no documents on creation, got %+v", event.Payload.Documents)
+		}
+	})
+
+	t.Run("should return error when outbox save fails", func(t *testing.T) {
+		outbox := &mockOutboxRepository{
+			saveFn: func(ctx context.Context, m *entities.OutboxMessage) error {
+				return errors.New("database error")
 			},
 		}
-		logger := &mockLogger{}
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
-		req := newRequestBuilder().build()
+		useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(outbox), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+		response, err := useCase.Execute(context.Background(), newRequestBuilder().build())
 
-		response, err := useCase.Execute(context.Background(), req)
+		assertError(t, err)
+		assertApplicationError(t, err, "INTERNAL_ERROR", 500)
+		if response != nil {
+			t.Error("expected nil response")
+		}
+	})
+
+	t.Run("should not request analysis on creation when awaiting documents", func(t *testing.T) {
+		outbox := &mockOutboxRepository{}
+		saved := false
+		repo := &mockRepository{
+			saveFn: func(ctx context.Context, p *entities.Proposal) error {
+				saved = true
+				return nil
+			},
+		}
+
+		useCase := NewCreateProposalUseCase(repo, NewOutboxEventPublisher(outbox), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{AwaitDocuments: true})
+		_, err := useCase.Execute(context.Background(), newRequestBuilder().build())
 
 		assertNoError(t, err)
-		if publishedEvent == nil {
-			t.Fatal("expected event to be published")
+		if !saved {
+			t.Error("expected proposal to be saved")
 		}
-		if publishedEvent.EventType != events.EventProposalCreated {
-			t.Errorf("expected event type %q, got %q", events.EventProposalCreated, publishedEvent.EventType)
+		if len(outbox.saved) != 0 {
+			t.Errorf("expected no outbox message, got %d", len(outbox.saved))
 		}
-		if publishedEvent.ProposalID != response.ID {
-			t.Error("event proposal ID doesn't match response ID")
+	})
+
+	t.Run("should accept ISO 8601 birth dates", func(t *testing.T) {
+		useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{})
+
+		response, err := useCase.Execute(context.Background(), newRequestBuilder().withBirthDate("1990-01-15").build())
+
+		assertNoError(t, err)
+		if !response.BirthDate.Equal(time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected birth date 1990-01-15, got %v", response.BirthDate)
 		}
 	})
 
-	t.Run("should continue when event publishing fails", func(t *testing.T) {
-		repo := &mockRepository{}
-		producer := &mockQueueProducer{
-			publishFn: func(ctx context.Context, event *events.ProposalCreatedEvent) error {
-				return errors.New("queue error")
-			},
+	t.Run("should enforce the applicant age range", func(t *testing.T) {
+		today := time.Now().UTC()
+		tests := []struct {
+			name      string
+			birthDate time.Time
+			code      string
+		}{
+			{"underage", today.AddDate(-17, 0, 0), "APPLICANT_UNDERAGE"},
+			{"above max age", today.AddDate(-71, 0, 0), "APPLICANT_ABOVE_MAX_AGE"},
+			{"future", today.AddDate(0, 0, 2), "BIRTH_DATE_IN_FUTURE"},
 		}
-		logger := &mockLogger{}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, &mockNotifier{}, &mockLogger{}, CreateProposalConfig{MaxApplicantAge: 70})
 
-		useCase := NewCreateProposalUseCase(repo, producer, logger)
-		req := newRequestBuilder().build()
+				_, err := useCase.Execute(context.Background(), newRequestBuilder().withBirthDate(tt.birthDate.Format(DateLayoutISO)).build())
 
-		response, err := useCase.Execute(context.Background(), req)
+				assertFields(t, err, appErrors.FieldError{Pointer: "/birthdate", Code: tt.code})
+			})
+		}
+	})
+
+	t.Run("should notify customer after creation", func(t *testing.T) {
+		notifier := &mockNotifier{}
+
+		useCase := NewCreateProposalUseCase(&mockRepository{}, NewOutboxEventPublisher(&mockOutboxRepository{}), &mockTxManager{}, notifier, &mockLogger{}, CreateProposalConfig{})
+		_, err := useCase.Execute(context.Background(), newRequestBuilder().build())
 
 		assertNoError(t, err)
-		if response == nil {
-			t.Error("expected response even when event publishing fails")
+		if len(notifier.notified) != 1 || notifier.notified[0] != entities.StatusPending {
+			t.Errorf("expected one pending notification, got %v", notifier.notified)
 		}
 	})
 }
@@ -155,7 +397,7 @@ func TestEntityToResponse(t *testing.T) {
 	proposal := &entities.Proposal{
 		ID:        uuid.New(),
 		FullName:  "John Doe",
-		CPF:       "12345678901",
+		CPF:       "12345678909",
 		Email:     "john@example.com",
 		Phone:     "11999999999",
 		BirthDate: time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC),
@@ -184,4 +426,27 @@ func TestEntityToResponse(t *testing.T) {
 	if response.Address.City != proposal.Address.City {
 		t.Error("Address city mismatch")
 	}
+	if response.Rejection != nil {
+		t.Error("expected no rejection for pending proposal")
+	}
+
+	proposal.Status = entities.StatusRejected
+	proposal.Rejection = &entities.RejectionReason{Code: "FRAUD_SUSPECTED", Message: "CPF failed fraud check"}
+	response = entityToResponse(proposal)
+
+	if response.Rejection == nil || response.Rejection.Code != "FRAUD_SUSPECTED" {
+		t.Errorf("expected rejection code FRAUD_SUSPECTED, got %+v", response.Rejection)
+	}
+	if response.Offer != nil {
+		t.Error("expected no offer for rejected proposal")
+	}
+
+	proposal.Status = entities.StatusOfferPending
+	proposal.Rejection = nil
+	proposal.Offer = &entities.CreditOffer{CreditLimit: money.MustParse("12000"), RiskTier: "MEDIUM", RiskScore: 500}
+	response = entityToResponse(proposal)
+
+	if response.Offer == nil || response.Offer.CreditLimit != money.MustParse("12000") || response.Offer.RiskTier != "MEDIUM" {
+		t.Errorf("expected offer 12000/MEDIUM, got %+v", response.Offer)
+	}
 }
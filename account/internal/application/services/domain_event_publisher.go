@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+// domainEventPublisher lets use cases publish events inside their own
+// transaction.
+type domainEventPublisher interface {
+	Publish(ctx context.Context, evts ...events.DomainEvent) error
+}
+
+// OutboxEventPublisher writes domain events to the outbox, routed to their
+// queue. OutboxRelay sends them once the surrounding transaction commits.
+type OutboxEventPublisher struct {
+	outbox ports.OutboxRepository
+}
+
+func NewOutboxEventPublisher(outbox ports.OutboxRepository) *OutboxEventPublisher {
+	return &OutboxEventPublisher{outbox: outbox}
+}
+
+func (p *OutboxEventPublisher) Publish(ctx context.Context, evts ...events.DomainEvent) error {
+	for _, event := range evts {
+		message, err := entities.NewOutboxMessage(queueFor(event), event)
+		if err != nil {
+			return fmt.Errorf("build outbox message: %w", err)
+		}
+		if err := p.outbox.Save(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueFor routes ProposalCreated to risk-analysis and every other proposal
+// event to downstream consumers.
+func queueFor(event events.DomainEvent) string {
+	if event.EventName() == events.EventProposalCreated {
+		return ports.QueueProposals
+	}
+	return ports.QueueProposalEvents
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func TestOutboxEventPublisher_Publish(t *testing.T) {
+	t.Run("should route events to their queues", func(t *testing.T) {
+		proposalID := uuid.New()
+		outbox := &mockOutboxRepository{}
+		publisher := NewOutboxEventPublisher(outbox)
+
+		err := publisher.Publish(context.Background(),
+			&events.ProposalCreatedEvent{EventType: events.EventProposalCreated, ProposalID: proposalID},
+			&events.ProposalLifecycleEvent{EventType: events.EventProposalStatusChanged, ProposalID: proposalID},
+			&events.ProposalLifecycleEvent{EventType: events.EventProposalApproved, ProposalID: proposalID},
+		)
+
+		assertNoError(t, err)
+		want := []string{ports.QueueProposals, ports.QueueProposalEvents, ports.QueueProposalEvents}
+		if len(outbox.saved) != len(want) {
+			t.Fatalf("expected %d outbox messages, got %d", len(want), len(outbox.saved))
+		}
+		for i, message := range outbox.saved {
+			if message.Destination != want[i] {
+				t.Errorf("message %d: expected destination %q, got %q", i, want[i], message.Destination)
+			}
+			if message.AggregateID != proposalID {
+				t.Errorf("message %d: unexpected aggregate ID", i)
+			}
+		}
+	})
+}
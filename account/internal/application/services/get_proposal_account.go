@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+type GetProposalAccountUseCase struct {
+	repository ports.ProposalRepository
+	accounts   ports.AccountRepository
+	cards      ports.CardRepository
+}
+
+func NewGetProposalAccountUseCase(
+	repo ports.ProposalRepository,
+	accounts ports.AccountRepository,
+	cards ports.CardRepository,
+) *GetProposalAccountUseCase {
+	return &GetProposalAccountUseCase{
+		repository: repo,
+		accounts:   accounts,
+		cards:      cards,
+	}
+}
+
+func (uc *GetProposalAccountUseCase) Execute(ctx context.Context, id uuid.UUID) (*dto.AccountResponse, error) {
+	_, err := uc.repository.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+
+	account, err := uc.accounts.FindByProposalID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrAccountNotFound) {
+		return nil, appErrors.NewNotFoundError("account")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch account", err)
+	}
+
+	cards, err := uc.cards.FindByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch cards", err)
+	}
+
+	return accountToResponse(account, cards), nil
+}
+
+func accountToResponse(account *entities.CustomerAccount, cards []*entities.Card) *dto.AccountResponse {
+	response := &dto.AccountResponse{
+		ID:         account.ID,
+		ProposalID: account.ProposalID,
+		Branch:     account.Branch,
+		Number:     account.Number,
+		CheckDigit: account.CheckDigit,
+		Formatted:  account.FormattedNumber(),
+		Cards:      make([]*dto.CardResponse, 0, len(cards)),
+		CreatedAt:  account.CreatedAt,
+	}
+	for _, card := range cards {
+		response.Cards = append(response.Cards, &dto.CardResponse{
+			ID:          card.ID,
+			MaskedPAN:   card.MaskedPAN,
+			ExpiryMonth: card.ExpiryMonth,
+			ExpiryYear:  card.ExpiryYear,
+			Status:      string(card.Status),
+		})
+	}
+	return response
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+func TestGetProposalAccountUseCase_Execute(t *testing.T) {
+	t.Run("should return account with its cards", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAccepted)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		accounts := &mockAccountRepository{}
+		cards := &mockCardRepository{}
+		assertNoError(t, NewAccountIssuer(accounts, cards, &mockCardTokenizer{}, AccountIssuerConfig{}).Issue(context.Background(), proposal))
+
+		useCase := NewGetProposalAccountUseCase(repo, accounts, cards)
+		response, err := useCase.Execute(context.Background(), proposal.ID)
+
+		assertNoError(t, err)
+		if response.ProposalID != proposal.ID {
+			t.Errorf("expected proposal ID %v, got %v", proposal.ID, response.ProposalID)
+		}
+		if response.Formatted != response.Number+"-"+response.CheckDigit {
+			t.Errorf("unexpected formatted number %q", response.Formatted)
+		}
+		if len(response.Cards) != 1 || response.Cards[0].MaskedPAN != cards.cards[0].MaskedPAN {
+			t.Errorf("expected masked card in response, got %+v", response.Cards)
+		}
+	})
+
+	t.Run("should return not found error when proposal has no account", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return newProposalWithStatus(entities.StatusAnalyzing), nil
+			},
+		}
+
+		useCase := NewGetProposalAccountUseCase(repo, &mockAccountRepository{}, &mockCardRepository{})
+		_, err := useCase.Execute(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+
+	t.Run("should return not found error when proposal does not exist", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return nil, domainErrors.ErrProposalNotFound
+			},
+		}
+
+		useCase := NewGetProposalAccountUseCase(repo, &mockAccountRepository{}, &mockCardRepository{})
+		_, err := useCase.Execute(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+}
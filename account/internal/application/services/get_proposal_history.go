@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+type GetProposalHistoryUseCase struct {
+	repository ports.ProposalRepository
+	history    ports.StatusHistoryRepository
+}
+
+func NewGetProposalHistoryUseCase(
+	repo ports.ProposalRepository,
+	history ports.StatusHistoryRepository,
+) *GetProposalHistoryUseCase {
+	return &GetProposalHistoryUseCase{
+		repository: repo,
+		history:    history,
+	}
+}
+
+func (uc *GetProposalHistoryUseCase) Execute(ctx context.Context, id uuid.UUID) (*dto.ProposalHistoryResponse, error) {
+	_, err := uc.repository.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+
+	entries, err := uc.history.FindByProposalID(ctx, id)
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal history", err)
+	}
+
+	response := &dto.ProposalHistoryResponse{
+		ProposalID: id,
+		Items:      make([]dto.StatusHistoryItem, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		response.Items = append(response.Items, dto.StatusHistoryItem{
+			PreviousStatus: string(entry.PreviousStatus),
+			Status:         string(entry.NewStatus),
+			EventType:      entry.EventType,
+			MessageID:      entry.MessageID,
+			OccurredAt:     entry.OccurredAt,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+func TestGetProposalHistoryUseCase_Execute(t *testing.T) {
+	t.Run("should return history entries in order", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusRejected)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		history := &mockStatusHistoryRepository{
+			findByProposalIDFn: func(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error) {
+				return []*entities.StatusHistoryEntry{
+					{ProposalID: proposalID, PreviousStatus: entities.StatusPending, NewStatus: entities.StatusAnalyzing, EventType: "DocumentsApproved", MessageID: "m1", OccurredAt: time.Now()},
+					{ProposalID: proposalID, PreviousStatus: entities.StatusAnalyzing, NewStatus: entities.StatusRejected, EventType: "FraudRejected", MessageID: "m2", OccurredAt: time.Now()},
+				}, nil
+			},
+		}
+
+		useCase := NewGetProposalHistoryUseCase(repo, history)
+		response, err := useCase.Execute(context.Background(), proposal.ID)
+
+		assertNoError(t, err)
+		if response.ProposalID != proposal.ID {
+			t.Errorf("expected proposal ID %v, got %v", proposal.ID, response.ProposalID)
+		}
+		if len(response.Items) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(response.Items))
+		}
+		if response.Items[1].Status != string(entities.StatusRejected) || response.Items[1].EventType != "FraudRejected" {
+			t.Errorf("unexpected last item: %+v", response.Items[1])
+		}
+	})
+
+	t.Run("should return not found error when proposal does not exist", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return nil, domainErrors.ErrProposalNotFound
+			},
+		}
+
+		useCase := NewGetProposalHistoryUseCase(repo, &mockStatusHistoryRepository{})
+		_, err := useCase.Execute(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+
+	t.Run("should return internal error when history lookup fails", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return newProposalWithStatus(entities.StatusPending), nil
+			},
+		}
+		history := &mockStatusHistoryRepository{
+			findByProposalIDFn: func(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		useCase := NewGetProposalHistoryUseCase(repo, history)
+		_, err := useCase.Execute(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "INTERNAL_ERROR", 500)
+	})
+}
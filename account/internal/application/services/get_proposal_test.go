@@ -16,7 +16,7 @@ func TestGetProposalUseCase_Execute(t *testing.T) {
 		expectedProposal := &entities.Proposal{
 			ID:        uuid.New(),
 			FullName:  "John Doe",
-			CPF:       "12345678901",
+			CPF:       "12345678909",
 			Email:     "john@example.com",
 			Phone:     "11999999999",
 			BirthDate: time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC),
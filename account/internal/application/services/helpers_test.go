@@ -4,18 +4,24 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
 	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
 	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
 	"github.com/google/uuid"
 )
 
 type mockRepository struct {
-	saveFn      func(ctx context.Context, p *entities.Proposal) error
-	findByCPFFn func(ctx context.Context, cpf string) (*entities.Proposal, error)
-	findByIDFn  func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error)
+	saveFn            func(ctx context.Context, p *entities.Proposal) error
+	findLatestByCPFFn func(ctx context.Context, cpf string) (*entities.Proposal, error)
+	findByIDFn        func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error)
+	listFn            func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error)
+	updateFn          func(ctx context.Context, p *entities.Proposal) error
+	updated           []*entities.Proposal
 }
 
 func (m *mockRepository) Save(ctx context.Context, p *entities.Proposal) error {
@@ -25,11 +31,11 @@ func (m *mockRepository) Save(ctx context.Context, p *entities.Proposal) error {
 	return nil
 }
 
-func (m *mockRepository) FindByCPF(ctx context.Context, cpf string) (*entities.Proposal, error) {
-	if m.findByCPFFn != nil {
-		return m.findByCPFFn(ctx, cpf)
+func (m *mockRepository) FindLatestByCPF(ctx context.Context, cpf string) (*entities.Proposal, error) {
+	if m.findLatestByCPFFn != nil {
+		return m.findLatestByCPFFn(ctx, cpf)
 	}
-	return nil, nil
+	return nil, events.ErrProposalNotFound
 }
 
 func (m *mockRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
@@ -40,20 +46,275 @@ func (m *mockRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.
 }
 
 func (m *mockRepository) Update(ctx context.Context, p *entities.Proposal) error {
+	if m.updateFn != nil {
+		return m.updateFn(ctx, p)
+	}
+	m.updated = append(m.updated, p)
 	return nil
 }
 
+func (m *mockRepository) List(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, filter)
+	}
+	return nil, nil
+}
+
 type mockQueueProducer struct {
-	publishFn func(ctx context.Context, event *events.ProposalCreatedEvent) error
+	publishFn func(ctx context.Context, queue string, body []byte) error
 }
 
-func (m *mockQueueProducer) Publish(ctx context.Context, event *events.ProposalCreatedEvent) error {
+func (m *mockQueueProducer) Publish(ctx context.Context, queue string, body []byte) error {
 	if m.publishFn != nil {
-		return m.publishFn(ctx, event)
+		return m.publishFn(ctx, queue, body)
 	}
 	return nil
 }
 
+type mockTxManager struct{}
+
+func (m *mockTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type mockOutboxRepository struct {
+	saveFn         func(ctx context.Context, m *entities.OutboxMessage) error
+	fetchPendingFn func(ctx context.Context, limit int) ([]*entities.OutboxMessage, error)
+	statsFn        func(ctx context.Context, stuckAttempts int) (*ports.OutboxStats, error)
+	saved          []*entities.OutboxMessage
+	updated        []*entities.OutboxMessage
+}
+
+func (m *mockOutboxRepository) Save(ctx context.Context, msg *entities.OutboxMessage) error {
+	if m.saveFn != nil {
+		return m.saveFn(ctx, msg)
+	}
+	m.saved = append(m.saved, msg)
+	return nil
+}
+
+func (m *mockOutboxRepository) FetchPending(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+	if m.fetchPendingFn != nil {
+		return m.fetchPendingFn(ctx, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockOutboxRepository) Update(ctx context.Context, msg *entities.OutboxMessage) error {
+	m.updated = append(m.updated, msg)
+	return nil
+}
+
+func (m *mockOutboxRepository) Stats(ctx context.Context, stuckAttempts int) (*ports.OutboxStats, error) {
+	if m.statsFn != nil {
+		return m.statsFn(ctx, stuckAttempts)
+	}
+	return &ports.OutboxStats{}, nil
+}
+
+type mockStatusHistoryRepository struct {
+	findByProposalIDFn func(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error)
+	saved              []*entities.StatusHistoryEntry
+}
+
+func (m *mockStatusHistoryRepository) Save(ctx context.Context, entry *entities.StatusHistoryEntry) error {
+	m.saved = append(m.saved, entry)
+	return nil
+}
+
+func (m *mockStatusHistoryRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error) {
+	if m.findByProposalIDFn != nil {
+		return m.findByProposalIDFn(ctx, proposalID)
+	}
+	return nil, nil
+}
+
+type mockNotifier struct {
+	notified []entities.ProposalStatus
+}
+
+func (m *mockNotifier) NotifyStatus(ctx context.Context, proposal *entities.Proposal) {
+	m.notified = append(m.notified, proposal.Status)
+}
+
+type mockDomainEventPublisher struct {
+	published []events.DomainEvent
+}
+
+func (m *mockDomainEventPublisher) Publish(ctx context.Context, evts ...events.DomainEvent) error {
+	m.published = append(m.published, evts...)
+	return nil
+}
+
+type mockWebhookEnqueuer struct {
+	enqueued []entities.ProposalStatus
+}
+
+func (m *mockWebhookEnqueuer) Enqueue(ctx context.Context, proposal *entities.Proposal, previous entities.ProposalStatus) error {
+	m.enqueued = append(m.enqueued, proposal.Status)
+	return nil
+}
+
+type mockWebhookSubscriptionRepository struct {
+	subscriptions []*entities.WebhookSubscription
+}
+
+func (m *mockWebhookSubscriptionRepository) Save(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	m.subscriptions = append(m.subscriptions, subscription)
+	return nil
+}
+
+func (m *mockWebhookSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	for _, subscription := range m.subscriptions {
+		if subscription.ID == id {
+			return subscription, nil
+		}
+	}
+	return nil, events.ErrWebhookSubscriptionNotFound
+}
+
+func (m *mockWebhookSubscriptionRepository) List(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	return m.subscriptions, nil
+}
+
+type mockWebhookDeliveryRepository struct {
+	deliveries []*entities.WebhookDelivery
+	updated    []*entities.WebhookDelivery
+	leaseUntil time.Time
+}
+
+func (m *mockWebhookDeliveryRepository) Save(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *mockWebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*entities.WebhookDelivery, error) {
+	m.leaseUntil = leaseUntil
+	for _, delivery := range m.deliveries {
+		delivery.NextAttemptAt = leaseUntil
+	}
+	return m.deliveries, nil
+}
+
+func (m *mockWebhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id {
+			return delivery, nil
+		}
+	}
+	return nil, events.ErrWebhookDeliveryNotFound
+}
+
+func (m *mockWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	m.updated = append(m.updated, delivery)
+	return nil
+}
+
+func (m *mockWebhookDeliveryRepository) List(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	return m.deliveries, nil
+}
+
+type mockWebhookSender struct {
+	sendFn func(ctx context.Context, request *ports.WebhookRequest) error
+	sent   []*ports.WebhookRequest
+}
+
+func (m *mockWebhookSender) Send(ctx context.Context, request *ports.WebhookRequest) error {
+	m.sent = append(m.sent, request)
+	if m.sendFn != nil {
+		return m.sendFn(ctx, request)
+	}
+	return nil
+}
+
+type mockProposalEventBroker struct {
+	published []*ports.ProposalEvent
+	events    chan *ports.ProposalEvent
+	cancelled bool
+}
+
+func (m *mockProposalEventBroker) Publish(ctx context.Context, event *ports.ProposalEvent) error {
+	m.published = append(m.published, event)
+	return nil
+}
+
+func (m *mockProposalEventBroker) Subscribe(ctx context.Context, proposalID uuid.UUID) (<-chan *ports.ProposalEvent, func(), error) {
+	if m.events == nil {
+		m.events = make(chan *ports.ProposalEvent, 8)
+	}
+	return m.events, func() { m.cancelled = true }, nil
+}
+
+type mockAccountIssuer struct {
+	issued []uuid.UUID
+}
+
+func (m *mockAccountIssuer) Issue(ctx context.Context, proposal *entities.Proposal) error {
+	m.issued = append(m.issued, proposal.ID)
+	return nil
+}
+
+type mockOfferEvidenceRepository struct {
+	saved []*entities.OfferEvidence
+}
+
+func (m *mockOfferEvidenceRepository) Save(ctx context.Context, evidence *entities.OfferEvidence) error {
+	m.saved = append(m.saved, evidence)
+	return nil
+}
+
+type mockReviewDecisionRepository struct {
+	saved []*entities.ReviewDecision
+}
+
+func (m *mockReviewDecisionRepository) Save(ctx context.Context, decision *entities.ReviewDecision) error {
+	m.saved = append(m.saved, decision)
+	return nil
+}
+
+type mockDocumentRepository struct {
+	existing []*entities.Document
+	saved    []*entities.Document
+	// usedByOtherCPF holds the hashes already uploaded by another CPF.
+	usedByOtherCPF map[string]bool
+}
+
+func (m *mockDocumentRepository) Save(ctx context.Context, document *entities.Document) error {
+	m.saved = append(m.saved, document)
+	return nil
+}
+
+func (m *mockDocumentRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.Document, error) {
+	return append(m.existing, m.saved...), nil
+}
+
+func (m *mockDocumentRepository) ExistsForOtherCPF(ctx context.Context, sha256 string, cpf string) (bool, error) {
+	return m.usedByOtherCPF[sha256], nil
+}
+
+type mockDocumentStore struct {
+	putErr  error
+	stored  map[string][]byte
+	deleted []string
+}
+
+func (m *mockDocumentStore) Put(ctx context.Context, key string, contentType string, content []byte) error {
+	if m.putErr != nil {
+		return m.putErr
+	}
+	if m.stored == nil {
+		m.stored = make(map[string][]byte)
+	}
+	m.stored[key] = content
+	return nil
+}
+
+func (m *mockDocumentStore) Delete(ctx context.Context, key string) error {
+	m.deleted = append(m.deleted, key)
+	delete(m.stored, key)
+	return nil
+}
+
 type mockLogger struct {
 	infoFn  func(ctx context.Context, msg string, args ...interface{})
 	errorFn func(ctx context.Context, msg string, args ...interface{})
@@ -76,7 +337,7 @@ func (m *mockLogger) Warn(ctx context.Context, msg string, args ...interface{})
 type requestBuilder struct {
 	fullName  string
 	cpf       string
-	salary    float64
+	salary    money.Money
 	email     string
 	phone     string
 	birthDate string
@@ -86,8 +347,8 @@ type requestBuilder struct {
 func newRequestBuilder() *requestBuilder {
 	return &requestBuilder{
 		fullName:  "John Doe",
-		cpf:       "12345678901",
-		salary:    5000.00,
+		cpf:       "12345678909",
+		salary:    money.MustParse("5000.00"),
 		email:     "john@example.com",
 		phone:     "11999999999",
 		birthDate: "15-01-1990",
@@ -105,6 +366,21 @@ func (b *requestBuilder) withCPF(cpf string) *requestBuilder {
 	return b
 }
 
+func (b *requestBuilder) withEmail(email string) *requestBuilder {
+	b.email = email
+	return b
+}
+
+func (b *requestBuilder) withPhone(phone string) *requestBuilder {
+	b.phone = phone
+	return b
+}
+
+func (b *requestBuilder) withAddress(address dto.AddressRequest) *requestBuilder {
+	b.address = address
+	return b
+}
+
 func (b *requestBuilder) withBirthDate(date string) *requestBuilder {
 	b.birthDate = date
 	return b
@@ -136,6 +412,26 @@ func assertError(t *testing.T, err error) {
 	}
 }
 
+// assertFields checks the pointer and code of every field error, in order.
+func assertFields(t *testing.T, err error, want ...appErrors.FieldError) {
+	t.Helper()
+	var appErr *appErrors.ApplicationError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected ApplicationError, got %T", err)
+	}
+	if len(appErr.Fields) != len(want) {
+		t.Fatalf("expected %d field errors, got %+v", len(want), appErr.Fields)
+	}
+	for i, field := range appErr.Fields {
+		if field.Pointer != want[i].Pointer || field.Code != want[i].Code {
+			t.Errorf("expected field error %s %s, got %s %s", want[i].Pointer, want[i].Code, field.Pointer, field.Code)
+		}
+		if field.Message == "" {
+			t.Errorf("expected a message for %s", field.Pointer)
+		}
+	}
+}
+
 func assertApplicationError(t *testing.T, err error, expectedCode string, expectedStatus int) {
 	t.Helper()
 	var appErr *appErrors.ApplicationError
@@ -150,3 +446,17 @@ func assertApplicationError(t *testing.T, err error, expectedCode string, expect
 		t.Errorf("expected status %d, got %d", expectedStatus, appErr.StatusCode)
 	}
 }
+
+type mockJobLock struct {
+	heldElsewhere bool
+	locked        []string
+	unlocked      int
+}
+
+func (m *mockJobLock) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if m.heldElsewhere {
+		return nil, false, nil
+	}
+	m.locked = append(m.locked, name)
+	return func() { m.unlocked++ }, true, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type IdempotencyKeyPurgerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// IdempotencyKeyPurger deletes idempotency keys past their expiry, so the
+// table only holds keys that can still be replayed.
+type IdempotencyKeyPurger struct {
+	repository ports.IdempotencyRepository
+	logger     ports.Logger
+	cfg        IdempotencyKeyPurgerConfig
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+func NewIdempotencyKeyPurger(
+	repo ports.IdempotencyRepository,
+	logger ports.Logger,
+	cfg IdempotencyKeyPurgerConfig,
+) *IdempotencyKeyPurger {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = time.Hour
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1000
+	}
+
+	return &IdempotencyKeyPurger{
+		repository: repo,
+		logger:     logger,
+		cfg:        cfg,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+func (p *IdempotencyKeyPurger) Start(ctx context.Context) error {
+	p.wg.Add(1)
+	go p.run(ctx)
+	return nil
+}
+
+func (p *IdempotencyKeyPurger) Stop() error {
+	close(p.stopCh)
+	p.wg.Wait()
+	return nil
+}
+
+func (p *IdempotencyKeyPurger) run(ctx context.Context) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			if err := p.PurgeExpired(ctx); err != nil {
+				p.logger.Error(ctx, "failed to purge idempotency keys", "error", err)
+			}
+		}
+	}
+}
+
+// PurgeExpired deletes expired keys in batches until a batch comes back
+// short.
+func (p *IdempotencyKeyPurger) PurgeExpired(ctx context.Context) error {
+	now := time.Now()
+	total := 0
+	for {
+		deleted, err := p.repository.DeleteExpired(ctx, now, p.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		total += deleted
+		if deleted < p.cfg.BatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info(ctx, "idempotency keys purged", "count", total)
+	}
+	return nil
+}
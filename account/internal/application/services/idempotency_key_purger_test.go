@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type mockIdempotencyRepository struct {
+	ports.IdempotencyRepository
+	deleteExpiredFn func(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+func (m *mockIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	return m.deleteExpiredFn(ctx, before, limit)
+}
+
+func TestIdempotencyKeyPurger_PurgeExpired(t *testing.T) {
+	t.Run("should delete batches until one comes back short", func(t *testing.T) {
+		batches := []int{2, 2, 1}
+		calls := 0
+		repo := &mockIdempotencyRepository{
+			deleteExpiredFn: func(ctx context.Context, before time.Time, limit int) (int, error) {
+				if limit != 2 {
+					t.Errorf("expected limit 2, got %d", limit)
+				}
+				deleted := batches[calls]
+				calls++
+				return deleted, nil
+			},
+		}
+		purger := NewIdempotencyKeyPurger(repo, &mockLogger{}, IdempotencyKeyPurgerConfig{BatchSize: 2})
+
+		assertNoError(t, purger.PurgeExpired(context.Background()))
+		if calls != 3 {
+			t.Errorf("expected 3 batches, got %d", calls)
+		}
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		repo := &mockIdempotencyRepository{
+			deleteExpiredFn: func(ctx context.Context, before time.Time, limit int) (int, error) {
+				return 0, errors.New("db down")
+			},
+		}
+		purger := NewIdempotencyKeyPurger(repo, &mockLogger{}, IdempotencyKeyPurgerConfig{})
+
+		if err := purger.PurgeExpired(context.Background()); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
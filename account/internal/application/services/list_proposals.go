@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+	defaultListSort  = "-created_at"
+)
+
+var (
+	ErrInvalidStatus    = errors.New("invalid status filter")
+	ErrInvalidDate      = errors.New("invalid date filter, expected YYYY-MM-DD or RFC 3339")
+	ErrInvalidSort      = errors.New("invalid sort, expected created_at, -created_at, updated_at or -updated_at")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrInvalidLimit     = errors.New("invalid limit")
+	ErrInvalidCPFPrefix = errors.New("cpf prefix must contain only digits")
+)
+
+type ListProposalsUseCase struct {
+	repository ports.ProposalRepository
+}
+
+func NewListProposalsUseCase(repo ports.ProposalRepository) *ListProposalsUseCase {
+	return &ListProposalsUseCase{repository: repo}
+}
+
+func (uc *ListProposalsUseCase) Execute(
+	ctx context.Context,
+	req *dto.ListProposalsRequest,
+) (*dto.ProposalListResponse, error) {
+	filter, err := buildProposalFilter(req)
+	if err != nil {
+		return nil, appErrors.NewInvalidInputError(err)
+	}
+
+	// Fetch one extra row to know whether there is a next page.
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	proposals, err := uc.repository.List(ctx, *filter)
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to list proposals", err)
+	}
+
+	response := &dto.ProposalListResponse{Items: make([]*dto.ProposalResponse, 0, len(proposals))}
+	if len(proposals) > limit {
+		proposals = proposals[:limit]
+		response.NextCursor = encodeCursor(filter, proposals[len(proposals)-1])
+	}
+	for _, p := range proposals {
+		response.Items = append(response.Items, entityToResponse(p))
+	}
+	return response, nil
+}
+
+func buildProposalFilter(req *dto.ListProposalsRequest) (*ports.ProposalFilter, error) {
+	filter := &ports.ProposalFilter{
+		State: strings.ToUpper(req.State),
+		Limit: DefaultListLimit,
+	}
+
+	if req.Status != "" {
+		status := entities.ProposalStatus(req.Status)
+		if !status.IsKnown() {
+			return nil, ErrInvalidStatus
+		}
+		filter.Status = status
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDateFilter(req.CreatedFrom, false); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseDateFilter(req.CreatedTo, true); err != nil {
+		return nil, err
+	}
+
+	if req.CPFPrefix != "" {
+		if strings.Trim(req.CPFPrefix, "0123456789") != "" || len(req.CPFPrefix) > 11 {
+			return nil, ErrInvalidCPFPrefix
+		}
+		filter.CPFPrefix = req.CPFPrefix
+	}
+
+	if req.Limit < 0 || req.Limit > MaxListLimit {
+		return nil, ErrInvalidLimit
+	}
+	if req.Limit > 0 {
+		filter.Limit = req.Limit
+	}
+
+	sort := req.Sort
+	if sort == "" {
+		sort = defaultListSort
+	}
+	field := ports.ProposalSortField(strings.TrimPrefix(sort, "-"))
+	if field != ports.SortByCreatedAt && field != ports.SortByUpdatedAt {
+		return nil, ErrInvalidSort
+	}
+	filter.SortField = field
+	filter.Descending = strings.HasPrefix(sort, "-")
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+// parseDateFilter accepts a date or a timestamp. A plain date used as upper
+// bound includes the whole day.
+func parseDateFilter(value string, upperBound bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+	if upperBound {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+type cursorToken struct {
+	Sort      string    `json:"s"`
+	SortValue time.Time `json:"v"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func encodeCursor(filter *ports.ProposalFilter, last *entities.Proposal) string {
+	sort := string(filter.SortField)
+	if filter.Descending {
+		sort = "-" + sort
+	}
+
+	token := cursorToken{Sort: sort, SortValue: last.CreatedAt, ID: last.ID}
+	if filter.SortField == ports.SortByUpdatedAt {
+		token.SortValue = last.UpdatedAt
+	}
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value, sort string) (*ports.ProposalCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var token cursorToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	if token.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidCursor, token.Sort)
+	}
+	return &ports.ProposalCursor{SortValue: token.SortValue, ID: token.ID}, nil
+}
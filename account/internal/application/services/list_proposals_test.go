@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func newListedProposals(n int) []*entities.Proposal {
+	proposals := make([]*entities.Proposal, 0, n)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		proposals = append(proposals, &entities.Proposal{
+			ID:        uuid.New(),
+			CPF:       "12345678909",
+			Status:    entities.StatusPending,
+			CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+			UpdatedAt: base,
+		})
+	}
+	return proposals
+}
+
+func TestListProposalsUseCase_Execute(t *testing.T) {
+	t.Run("should build filter from request", func(t *testing.T) {
+		var received ports.ProposalFilter
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				received = filter
+				return nil, nil
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		_, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{
+			Status:      "analyzing",
+			CreatedFrom: "2025-01-01",
+			CreatedTo:   "2025-01-31",
+			State:       "sp",
+			CPFPrefix:   "123",
+			Sort:        "updated_at",
+			Limit:       10,
+		})
+
+		assertNoError(t, err)
+		if received.Status != entities.StatusAnalyzing {
+			t.Errorf("expected status %q, got %q", entities.StatusAnalyzing, received.Status)
+		}
+		if received.State != "SP" {
+			t.Errorf("expected state SP, got %q", received.State)
+		}
+		if received.CPFPrefix != "123" {
+			t.Errorf("expected cpf prefix 123, got %q", received.CPFPrefix)
+		}
+		if received.SortField != ports.SortByUpdatedAt || received.Descending {
+			t.Errorf("expected ascending updated_at sort, got %q desc=%v", received.SortField, received.Descending)
+		}
+		if received.Limit != 11 {
+			t.Errorf("expected limit 11 (page size + 1), got %d", received.Limit)
+		}
+		wantTo := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		if received.CreatedTo == nil || !received.CreatedTo.Equal(wantTo) {
+			t.Errorf("expected created_to %v, got %v", wantTo, received.CreatedTo)
+		}
+	})
+
+	t.Run("should default to newest first", func(t *testing.T) {
+		var received ports.ProposalFilter
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				received = filter
+				return nil, nil
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		response, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{})
+
+		assertNoError(t, err)
+		if received.SortField != ports.SortByCreatedAt || !received.Descending {
+			t.Errorf("expected descending created_at sort, got %q desc=%v", received.SortField, received.Descending)
+		}
+		if received.Limit != DefaultListLimit+1 {
+			t.Errorf("expected limit %d, got %d", DefaultListLimit+1, received.Limit)
+		}
+		if response.Items == nil {
+			t.Error("expected empty items slice, got nil")
+		}
+	})
+
+	t.Run("should return next cursor that resumes after last item", func(t *testing.T) {
+		proposals := newListedProposals(3)
+		var filters []ports.ProposalFilter
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				filters = append(filters, filter)
+				return proposals, nil
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		first, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{Limit: 2})
+
+		assertNoError(t, err)
+		if len(first.Items) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(first.Items))
+		}
+		if first.NextCursor == "" {
+			t.Fatal("expected next cursor")
+		}
+
+		_, err = useCase.Execute(context.Background(), &dto.ListProposalsRequest{Limit: 2, Cursor: first.NextCursor})
+
+		assertNoError(t, err)
+		after := filters[1].After
+		if after == nil {
+			t.Fatal("expected cursor to be applied")
+		}
+		if after.ID != proposals[1].ID || !after.SortValue.Equal(proposals[1].CreatedAt) {
+			t.Errorf("expected cursor at second item, got %+v", after)
+		}
+	})
+
+	t.Run("should omit next cursor on last page", func(t *testing.T) {
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				return newListedProposals(2), nil
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		response, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{Limit: 2})
+
+		assertNoError(t, err)
+		if response.NextCursor != "" {
+			t.Errorf("expected no next cursor, got %q", response.NextCursor)
+		}
+	})
+
+	t.Run("should return invalid input for bad filters", func(t *testing.T) {
+		tests := []struct {
+			name string
+			req  *dto.ListProposalsRequest
+		}{
+			{"unknown status", &dto.ListProposalsRequest{Status: "unknown"}},
+			{"bad date", &dto.ListProposalsRequest{CreatedFrom: "01-01-2025"}},
+			{"bad sort", &dto.ListProposalsRequest{Sort: "cpf"}},
+			{"limit too high", &dto.ListProposalsRequest{Limit: MaxListLimit + 1}},
+			{"non digit cpf prefix", &dto.ListProposalsRequest{CPFPrefix: "12%"}},
+			{"garbage cursor", &dto.ListProposalsRequest{Cursor: "not-a-cursor"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				useCase := NewListProposalsUseCase(&mockRepository{})
+				_, err := useCase.Execute(context.Background(), tt.req)
+				assertApplicationError(t, err, "INVALID_INPUT", 400)
+			})
+		}
+	})
+
+	t.Run("should reject cursor issued for another sort", func(t *testing.T) {
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				return newListedProposals(2), nil
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		first, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{Limit: 1})
+		assertNoError(t, err)
+
+		_, err = useCase.Execute(context.Background(), &dto.ListProposalsRequest{Limit: 1, Sort: "updated_at", Cursor: first.NextCursor})
+		assertApplicationError(t, err, "INVALID_INPUT", 400)
+	})
+
+	t.Run("should return internal error when repository fails", func(t *testing.T) {
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		useCase := NewListProposalsUseCase(repo)
+		_, err := useCase.Execute(context.Background(), &dto.ListProposalsRequest{})
+
+		assertApplicationError(t, err, "INTERNAL_ERROR", 500)
+	})
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type NotificationQueueConfig struct {
+	Size    int
+	Workers int
+}
+
+type notificationJob struct {
+	ctx      context.Context
+	proposal entities.Proposal
+}
+
+// NotificationQueue hands status notifications to a fixed pool of workers so
+// a slow SMTP server or SMS gateway never holds an HTTP request or a queue
+// message. When the queue is full the notification is logged and dropped.
+type NotificationQueue struct {
+	notifier statusNotifier
+	logger   ports.Logger
+	cfg      NotificationQueueConfig
+	jobs     chan notificationJob
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewNotificationQueue(
+	notifier statusNotifier,
+	logger ports.Logger,
+	cfg NotificationQueueConfig,
+) *NotificationQueue {
+	if cfg.Size == 0 {
+		cfg.Size = 256
+	}
+	if cfg.Workers == 0 {
+		cfg.Workers = 4
+	}
+
+	return &NotificationQueue{
+		notifier: notifier,
+		logger:   logger,
+		cfg:      cfg,
+		jobs:     make(chan notificationJob, cfg.Size),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// NotifyStatus enqueues a copy of the proposal, so later changes made by the
+// caller do not leak into the message, and returns right away.
+func (q *NotificationQueue) NotifyStatus(ctx context.Context, proposal *entities.Proposal) {
+	job := notificationJob{ctx: context.WithoutCancel(ctx), proposal: *proposal}
+	select {
+	case q.jobs <- job:
+	default:
+		q.logger.Error(ctx, "notification queue is full, dropping notification", "proposal_id", proposal.ID, "status", proposal.Status)
+	}
+}
+
+func (q *NotificationQueue) Start(ctx context.Context) error {
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.wg.Add(1)
+		go q.run()
+	}
+	return nil
+}
+
+// Stop sends what is already queued and waits for the workers to finish.
+func (q *NotificationQueue) Stop() error {
+	close(q.stopCh)
+	q.wg.Wait()
+	return nil
+}
+
+func (q *NotificationQueue) run() {
+	defer q.wg.Done()
+	for {
+		select {
+		case job := <-q.jobs:
+			q.notifier.NotifyStatus(job.ctx, &job.proposal)
+		case <-q.stopCh:
+			for {
+				select {
+				case job := <-q.jobs:
+					q.notifier.NotifyStatus(job.ctx, &job.proposal)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
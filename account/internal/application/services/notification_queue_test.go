@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+type blockingNotifier struct {
+	release  chan struct{}
+	notified chan entities.ProposalStatus
+}
+
+func (m *blockingNotifier) NotifyStatus(ctx context.Context, proposal *entities.Proposal) {
+	<-m.release
+	if ctx.Err() != nil {
+		return
+	}
+	m.notified <- proposal.Status
+}
+
+func TestNotificationQueue_NotifyStatus(t *testing.T) {
+	t.Run("should return before the notification is sent", func(t *testing.T) {
+		notifier := &blockingNotifier{release: make(chan struct{}), notified: make(chan entities.ProposalStatus, 1)}
+		queue := NewNotificationQueue(notifier, &mockLogger{}, NotificationQueueConfig{Size: 1, Workers: 1})
+		_ = queue.Start(context.Background())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			queue.NotifyStatus(ctx, newProposalWithStatus(entities.StatusOfferPending))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected NotifyStatus not to wait for the notifier")
+		}
+
+		cancel()
+		close(notifier.release)
+		_ = queue.Stop()
+
+		select {
+		case status := <-notifier.notified:
+			if status != entities.StatusOfferPending {
+				t.Errorf("expected %s, got %s", entities.StatusOfferPending, status)
+			}
+		default:
+			t.Error("expected the notification to be sent even after the request ended")
+		}
+	})
+
+	t.Run("should send the status the proposal had when it was enqueued", func(t *testing.T) {
+		notifier := &blockingNotifier{release: make(chan struct{}), notified: make(chan entities.ProposalStatus, 1)}
+		queue := NewNotificationQueue(notifier, &mockLogger{}, NotificationQueueConfig{Size: 1, Workers: 1})
+
+		proposal := newProposalWithStatus(entities.StatusOfferPending)
+		queue.NotifyStatus(context.Background(), proposal)
+		proposal.Status = entities.StatusAccepted
+
+		_ = queue.Start(context.Background())
+		close(notifier.release)
+		_ = queue.Stop()
+
+		if status := <-notifier.notified; status != entities.StatusOfferPending {
+			t.Errorf("expected %s, got %s", entities.StatusOfferPending, status)
+		}
+	})
+
+	t.Run("should drop and log the notification when the queue is full", func(t *testing.T) {
+		notifier := &blockingNotifier{release: make(chan struct{}), notified: make(chan entities.ProposalStatus, 2)}
+		var logged []string
+		logger := &mockLogger{errorFn: func(ctx context.Context, msg string, args ...interface{}) {
+			logged = append(logged, msg)
+		}}
+		queue := NewNotificationQueue(notifier, logger, NotificationQueueConfig{Size: 1, Workers: 1})
+
+		queue.NotifyStatus(context.Background(), newProposalWithStatus(entities.StatusOfferPending))
+		queue.NotifyStatus(context.Background(), newProposalWithStatus(entities.StatusRejected))
+
+		if len(logged) != 1 {
+			t.Errorf("expected the dropped notification to be logged, got %v", logged)
+		}
+
+		_ = queue.Start(context.Background())
+		close(notifier.release)
+		_ = queue.Stop()
+
+		if len(notifier.notified) != 1 {
+			t.Errorf("expected only the queued notification to be sent, got %d", len(notifier.notified))
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"text/template"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+// statusNotifier lets use cases notify the customer without depending on
+// the concrete channels.
+type statusNotifier interface {
+	NotifyStatus(ctx context.Context, proposal *entities.Proposal)
+}
+
+// NotificationService tells the customer about proposal status changes on
+// every configured channel and records each delivery. Failures are logged
+// and never interrupt the proposal flow.
+type NotificationService struct {
+	notifiers  []ports.Notifier
+	deliveries ports.NotificationLogRepository
+	logger     ports.Logger
+}
+
+func NewNotificationService(
+	deliveries ports.NotificationLogRepository,
+	logger ports.Logger,
+	notifiers ...ports.Notifier,
+) *NotificationService {
+	return &NotificationService{
+		notifiers:  notifiers,
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+func (s *NotificationService) NotifyStatus(ctx context.Context, proposal *entities.Proposal) {
+	tmpl, ok := notificationTemplates[proposal.Status]
+	if !ok {
+		return
+	}
+
+	data := notificationData{
+		FirstName:  firstName(proposal.FullName),
+		ProposalID: proposal.ID.String(),
+	}
+
+	for _, notifier := range s.notifiers {
+		message, err := buildMessage(notifier.Channel(), proposal, tmpl, data)
+		if err != nil {
+			s.logger.Error(ctx, "failed to render notification", "proposal_id", proposal.ID, "channel", notifier.Channel(), "error", err)
+			continue
+		}
+		if message.Recipient == "" {
+			continue
+		}
+
+		sendErr := notifier.Send(ctx, message)
+		if sendErr != nil {
+			s.logger.Warn(ctx, "failed to send notification", "proposal_id", proposal.ID, "channel", notifier.Channel(), "error", sendErr)
+		}
+
+		delivery := entities.NewNotificationDelivery(proposal.ID, notifier.Channel(), message.Recipient, string(proposal.Status), sendErr)
+		if err := s.deliveries.Save(ctx, delivery); err != nil {
+			s.logger.Error(ctx, "failed to log notification delivery", "proposal_id", proposal.ID, "error", err)
+		}
+	}
+}
+
+func buildMessage(
+	channel string,
+	proposal *entities.Proposal,
+	tmpl notificationTemplate,
+	data notificationData,
+) (*ports.Message, error) {
+	message := &ports.Message{}
+	body := tmpl.sms
+
+	switch channel {
+	case ports.ChannelEmail:
+		message.Recipient = proposal.Email
+		body = tmpl.email
+		subject, err := render(tmpl.subject, data)
+		if err != nil {
+			return nil, err
+		}
+		message.Subject = subject
+	case ports.ChannelSMS:
+		message.Recipient = proposal.Phone
+	}
+
+	rendered, err := render(body, data)
+	if err != nil {
+		return nil, err
+	}
+	message.Body = rendered
+	return message, nil
+}
+
+func firstName(fullName string) string {
+	if names := strings.Fields(fullName); len(names) > 0 {
+		return names[0]
+	}
+	return fullName
+}
+
+func render(tmpl *template.Template, data notificationData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type mockChannelNotifier struct {
+	channel string
+	sendFn  func(ctx context.Context, message *ports.Message) error
+	sent    []*ports.Message
+}
+
+func (m *mockChannelNotifier) Channel() string {
+	return m.channel
+}
+
+func (m *mockChannelNotifier) Send(ctx context.Context, message *ports.Message) error {
+	m.sent = append(m.sent, message)
+	if m.sendFn != nil {
+		return m.sendFn(ctx, message)
+	}
+	return nil
+}
+
+type mockNotificationLogRepository struct {
+	saved []*entities.NotificationDelivery
+}
+
+func (m *mockNotificationLogRepository) Save(ctx context.Context, delivery *entities.NotificationDelivery) error {
+	m.saved = append(m.saved, delivery)
+	return nil
+}
+
+func newNotifiableProposal(status entities.ProposalStatus) *entities.Proposal {
+	proposal := newProposalWithStatus(status)
+	proposal.Email = "john@example.com"
+	proposal.Phone = "11999999999"
+	return proposal
+}
+
+func TestNotificationService_NotifyStatus(t *testing.T) {
+	t.Run("should send templated message on every channel", func(t *testing.T) {
+		email := &mockChannelNotifier{channel: ports.ChannelEmail}
+		sms := &mockChannelNotifier{channel: ports.ChannelSMS}
+		deliveries := &mockNotificationLogRepository{}
+		service := NewNotificationService(deliveries, &mockLogger{}, email, sms)
+
+		proposal := newNotifiableProposal(entities.StatusOfferPending)
+		service.NotifyStatus(context.Background(), proposal)
+
+		if len(email.sent) != 1 || len(sms.sent) != 1 {
+			t.Fatalf("expected 1 email and 1 sms, got %d and %d", len(email.sent), len(sms.sent))
+		}
+		if email.sent[0].Recipient != proposal.Email {
+			t.Errorf("expected email to %q, got %q", proposal.Email, email.sent[0].Recipient)
+		}
+		if email.sent[0].Subject != "Sua proposta foi aprovada" {
+			t.Errorf("unexpected subject %q", email.sent[0].Subject)
+		}
+		if !strings.Contains(email.sent[0].Body, "Olá, John!") || !strings.Contains(email.sent[0].Body, proposal.ID.String()) {
+			t.Errorf("unexpected email body %q", email.sent[0].Body)
+		}
+		if sms.sent[0].Recipient != proposal.Phone {
+			t.Errorf("expected sms to %q, got %q", proposal.Phone, sms.sent[0].Recipient)
+		}
+		if len(deliveries.saved) != 2 {
+			t.Fatalf("expected 2 delivery logs, got %d", len(deliveries.saved))
+		}
+		if deliveries.saved[0].Template != string(entities.StatusOfferPending) || deliveries.saved[0].Status != entities.DeliverySent {
+			t.Errorf("unexpected delivery log %+v", deliveries.saved[0])
+		}
+	})
+
+	t.Run("should log failed delivery and keep sending", func(t *testing.T) {
+		email := &mockChannelNotifier{
+			channel: ports.ChannelEmail,
+			sendFn: func(ctx context.Context, message *ports.Message) error {
+				return errors.New("smtp unavailable")
+			},
+		}
+		sms := &mockChannelNotifier{channel: ports.ChannelSMS}
+		deliveries := &mockNotificationLogRepository{}
+		service := NewNotificationService(deliveries, &mockLogger{}, email, sms)
+
+		service.NotifyStatus(context.Background(), newNotifiableProposal(entities.StatusRejected))
+
+		if len(sms.sent) != 1 {
+			t.Errorf("expected sms to be sent after email failure, got %d", len(sms.sent))
+		}
+		if len(deliveries.saved) != 2 {
+			t.Fatalf("expected 2 delivery logs, got %d", len(deliveries.saved))
+		}
+		failed := deliveries.saved[0]
+		if failed.Status != entities.DeliveryFailed || failed.Error != "smtp unavailable" {
+			t.Errorf("expected failed delivery with error, got %+v", failed)
+		}
+	})
+
+	t.Run("should skip channel without recipient", func(t *testing.T) {
+		sms := &mockChannelNotifier{channel: ports.ChannelSMS}
+		deliveries := &mockNotificationLogRepository{}
+		service := NewNotificationService(deliveries, &mockLogger{}, sms)
+
+		proposal := newNotifiableProposal(entities.StatusPending)
+		proposal.Phone = ""
+		service.NotifyStatus(context.Background(), proposal)
+
+		if len(sms.sent) != 0 || len(deliveries.saved) != 0 {
+			t.Errorf("expected nothing sent, got %d messages and %d logs", len(sms.sent), len(deliveries.saved))
+		}
+	})
+
+	t.Run("should have a template for every proposal status", func(t *testing.T) {
+		for _, status := range []entities.ProposalStatus{
+			entities.StatusPending,
+			entities.StatusAnalyzing,
+			entities.StatusUnderReview,
+			entities.StatusOfferPending,
+			entities.StatusAccepted,
+			entities.StatusDeclined,
+			entities.StatusOfferExpired,
+			entities.StatusRejected,
+			entities.StatusCancelled,
+			entities.StatusExpired,
+		} {
+			if _, ok := notificationTemplates[status]; !ok {
+				t.Errorf("missing notification template for status %q", status)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"text/template"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+// notificationTemplate holds the pt-BR messages sent for a proposal status.
+type notificationTemplate struct {
+	subject *template.Template
+	email   *template.Template
+	sms     *template.Template
+}
+
+type notificationData struct {
+	FirstName  string
+	ProposalID string
+}
+
+func newNotificationTemplate(subject, email, sms string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		email:   template.Must(template.New("email").Parse(email)),
+		sms:     template.Must(template.New("sms").Parse(sms)),
+	}
+}
+
+var notificationTemplates = map[entities.ProposalStatus]notificationTemplate{
+	entities.StatusPending: newNotificationTemplate(
+		"Recebemos sua proposta",
+		"Olá, {{.FirstName}}!\n\nRecebemos sua proposta de abertura de conta. Ela será analisada em instantes e avisaremos você a cada etapa.\n\nProtocolo: {{.ProposalID}}",
+		"Olá, {{.FirstName}}! Recebemos sua proposta de abertura de conta. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusAnalyzing: newNotificationTemplate(
+		"Sua proposta está em análise",
+		"Olá, {{.FirstName}}!\n\nSeus documentos foram validados e sua proposta está em análise de crédito.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, seus documentos foram validados e sua proposta está em análise.",
+	),
+	entities.StatusUnderReview: newNotificationTemplate(
+		"Sua proposta está em revisão",
+		"Olá, {{.FirstName}}!\n\nSua proposta precisa de uma análise adicional da nossa equipe. Avisaremos você assim que ela for concluída.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, sua proposta está em revisão pela nossa equipe. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusOfferPending: newNotificationTemplate(
+		"Sua proposta foi aprovada",
+		"Olá, {{.FirstName}}!\n\nBoas notícias: sua proposta foi aprovada. Confira a oferta do seu cartão e aceite-a para abrirmos sua conta.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, sua proposta foi aprovada! Aceite a oferta para abrirmos sua conta.",
+	),
+	entities.StatusAccepted: newNotificationTemplate(
+		"Sua conta foi aberta",
+		"Olá, {{.FirstName}}!\n\nRecebemos o aceite da sua oferta. Sua conta foi aberta e seu cartão virtual já está disponível.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, sua conta foi aberta e seu cartão virtual já está disponível!",
+	),
+	entities.StatusDeclined: newNotificationTemplate(
+		"Oferta recusada",
+		"Olá, {{.FirstName}}.\n\nRegistramos que você recusou a oferta. Se mudar de ideia, é só enviar uma nova proposta.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, registramos a recusa da sua oferta. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusOfferExpired: newNotificationTemplate(
+		"Sua oferta expirou",
+		"Olá, {{.FirstName}}.\n\nO prazo para aceitar a oferta do seu cartão terminou. Se ainda tiver interesse, envie uma nova proposta.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, o prazo para aceitar sua oferta terminou. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusRejected: newNotificationTemplate(
+		"Sua proposta não foi aprovada",
+		"Olá, {{.FirstName}}.\n\nInfelizmente sua proposta não foi aprovada neste momento. Em caso de dúvidas, fale com nosso atendimento informando o protocolo.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, infelizmente sua proposta não foi aprovada. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusCancelled: newNotificationTemplate(
+		"Sua proposta foi cancelada",
+		"Olá, {{.FirstName}}.\n\nConforme solicitado, cancelamos sua proposta de abertura de conta. Se quiser, você pode enviar uma nova proposta a qualquer momento.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, sua proposta foi cancelada conforme solicitado. Protocolo: {{.ProposalID}}",
+	),
+	entities.StatusExpired: newNotificationTemplate(
+		"Sua proposta expirou",
+		"Olá, {{.FirstName}}.\n\nNão conseguimos concluir a análise da sua proposta dentro do prazo, e ela foi encerrada. Confira se os documentos foram enviados e, se ainda tiver interesse, envie uma nova proposta.\n\nProtocolo: {{.ProposalID}}",
+		"{{.FirstName}}, sua proposta expirou sem conclusão da análise. Envie uma nova proposta se ainda tiver interesse. Protocolo: {{.ProposalID}}",
+	),
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+)
+
+const (
+	DefaultOfferTermsVersion = "v1"
+	DefaultOfferTTL          = 7 * 24 * time.Hour
+)
+
+// OfferConfig holds the card terms attached to every approved offer.
+type OfferConfig struct {
+	AnnualFee    money.Money
+	TermsVersion string
+	TTL          time.Duration
+}
+
+func (c OfferConfig) withDefaults() OfferConfig {
+	if c.TermsVersion == "" {
+		c.TermsVersion = DefaultOfferTermsVersion
+	}
+	if c.TTL == 0 {
+		c.TTL = DefaultOfferTTL
+	}
+	return c
+}
+
+// present attaches the card terms to the offer computed by risk-analysis.
+// The offer expires TTL after it is presented to the customer.
+func (c OfferConfig) present(offer entities.CreditOffer, now time.Time) entities.CreditOffer {
+	offer.AnnualFee = c.AnnualFee
+	offer.TermsVersion = c.TermsVersion
+	offer.ExpiresAt = now.Add(c.TTL)
+	return offer
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type OfferExpirerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// OfferExpirer moves offers that were neither accepted nor declined before
+// their deadline to offer_expired.
+type OfferExpirer struct {
+	repository  ports.ProposalRepository
+	transitions *ProposalTransitioner
+	logger      ports.Logger
+	cfg         OfferExpirerConfig
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewOfferExpirer(
+	repo ports.ProposalRepository,
+	transitions *ProposalTransitioner,
+	logger ports.Logger,
+	cfg OfferExpirerConfig,
+) *OfferExpirer {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = time.Minute
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 50
+	}
+
+	return &OfferExpirer{
+		repository:  repo,
+		transitions: transitions,
+		logger:      logger,
+		cfg:         cfg,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (e *OfferExpirer) Start(ctx context.Context) error {
+	e.wg.Add(1)
+	go e.run(ctx)
+	return nil
+}
+
+func (e *OfferExpirer) Stop() error {
+	close(e.stopCh)
+	e.wg.Wait()
+	return nil
+}
+
+func (e *OfferExpirer) run(ctx context.Context) {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			if err := e.ExpireDue(ctx); err != nil {
+				e.logger.Error(ctx, "failed to expire offers", "error", err)
+			}
+		}
+	}
+}
+
+// ExpireDue expires one batch of overdue offers. A failing proposal is
+// logged and retried on the next tick.
+func (e *OfferExpirer) ExpireDue(ctx context.Context) error {
+	now := time.Now()
+	proposals, err := e.repository.List(ctx, ports.ProposalFilter{
+		Status:         entities.StatusOfferPending,
+		OfferExpiredAt: &now,
+		Limit:          e.cfg.BatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, proposal := range proposals {
+		if err := e.transitions.Transition(ctx, proposal, events.EventProposalOfferExpired, "", proposal.ExpireOffer); err != nil {
+			e.logger.Warn(ctx, "failed to expire offer", "proposal_id", proposal.ID, "error", err)
+			continue
+		}
+		e.logger.Info(ctx, "offer expired", "proposal_id", proposal.ID)
+	}
+	return nil
+}
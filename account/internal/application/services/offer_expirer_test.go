@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+func TestOfferExpirer_ExpireDue(t *testing.T) {
+	t.Run("should expire overdue offers", func(t *testing.T) {
+		overdue := newOfferPendingProposal(time.Now().Add(-time.Minute))
+		var filter ports.ProposalFilter
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, f ports.ProposalFilter) ([]*entities.Proposal, error) {
+				filter = f
+				return []*entities.Proposal{overdue}, nil
+			},
+		}
+		notifier := &mockNotifier{}
+		transitions := NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, notifier, &mockLogger{})
+		expirer := NewOfferExpirer(repo, transitions, &mockLogger{}, OfferExpirerConfig{BatchSize: 5})
+
+		err := expirer.ExpireDue(context.Background())
+
+		assertNoError(t, err)
+		if filter.Status != entities.StatusOfferPending || filter.OfferExpiredAt == nil || filter.Limit != 5 {
+			t.Errorf("unexpected filter %+v", filter)
+		}
+		if overdue.Status != entities.StatusOfferExpired {
+			t.Errorf("expected status offer_expired, got %q", overdue.Status)
+		}
+		if len(notifier.notified) != 1 {
+			t.Errorf("expected customer notified, got %v", notifier.notified)
+		}
+	})
+
+	t.Run("should skip offers that are not due", func(t *testing.T) {
+		notDue := newOfferPendingProposal(time.Now().Add(time.Hour))
+		repo := &mockRepository{
+			listFn: func(ctx context.Context, f ports.ProposalFilter) ([]*entities.Proposal, error) {
+				return []*entities.Proposal{notDue}, nil
+			},
+		}
+		transitions := NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{})
+		expirer := NewOfferExpirer(repo, transitions, &mockLogger{}, OfferExpirerConfig{})
+
+		assertNoError(t, expirer.ExpireDue(context.Background()))
+		if notDue.Status != entities.StatusOfferPending || len(repo.updated) != 0 {
+			t.Errorf("expected offer to stay pending, got %q", notDue.Status)
+		}
+	})
+}
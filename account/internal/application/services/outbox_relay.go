@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval  time.Duration
+	BatchSize     int
+	BaseBackoff   time.Duration
+	MaxBackoff    time.Duration
+	StuckAttempts int
+}
+
+// OutboxRelay publishes pending outbox messages through the queue producer,
+// retrying failed deliveries with exponential backoff.
+type OutboxRelay struct {
+	outbox    ports.OutboxRepository
+	producer  ports.QueueProducer
+	txManager ports.TransactionManager
+	logger    ports.Logger
+	cfg       OutboxRelayConfig
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewOutboxRelay(
+	outbox ports.OutboxRepository,
+	producer ports.QueueProducer,
+	txManager ports.TransactionManager,
+	logger ports.Logger,
+	cfg OutboxRelayConfig,
+) *OutboxRelay {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 10
+	}
+	if cfg.BaseBackoff == 0 {
+		cfg.BaseBackoff = time.Second
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.StuckAttempts == 0 {
+		cfg.StuckAttempts = 5
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		producer:  producer,
+		txManager: txManager,
+		logger:    logger,
+		cfg:       cfg,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	r.wg.Add(1)
+	go r.run(ctx)
+	return nil
+}
+
+func (r *OutboxRelay) Stop() error {
+	close(r.stopCh)
+	r.wg.Wait()
+	return nil
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if err := r.RelayPending(ctx); err != nil {
+				r.logger.Error(ctx, "failed to relay outbox messages", "error", err)
+			}
+		}
+	}
+}
+
+// RelayPending publishes one batch of due messages. Messages that fail are
+// rescheduled and stay in the outbox.
+func (r *OutboxRelay) RelayPending(ctx context.Context) error {
+	return r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		messages, err := r.outbox.FetchPending(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err := r.publish(ctx, message); err != nil {
+				message.MarkFailed(err, time.Now().Add(exponentialBackoff(r.cfg.BaseBackoff, r.cfg.MaxBackoff, message.Attempts+1)))
+				r.logger.Warn(ctx, "failed to publish outbox message",
+					"message_id", message.ID, "attempts", message.Attempts, "error", err)
+				if message.Attempts == r.cfg.StuckAttempts {
+					r.logger.Error(ctx, "outbox message is stuck", "message_id", message.ID, "event_type", message.EventType)
+				}
+			} else {
+				message.MarkSent()
+			}
+
+			if err := r.outbox.Update(ctx, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *OutboxRelay) Stats(ctx context.Context) (*ports.OutboxStats, error) {
+	return r.outbox.Stats(ctx, r.cfg.StuckAttempts)
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message *entities.OutboxMessage) error {
+	return r.producer.Publish(ctx, message.Destination, message.Payload)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func newPendingOutboxMessage(t *testing.T) *entities.OutboxMessage {
+	t.Helper()
+	proposalID := uuid.New()
+	message, err := entities.NewOutboxMessage(ports.QueueProposals, &events.ProposalCreatedEvent{
+		EventType:  events.EventProposalCreated,
+		ProposalID: proposalID,
+		Payload:    &events.ProposalPayload{FullName: "John Doe", CPF: "12345678909", Salary: money.MustParse("5000")},
+	})
+	if err != nil {
+		t.Fatalf("failed to build outbox message: %v", err)
+	}
+	return message
+}
+
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	t.Run("should publish pending messages and mark them sent", func(t *testing.T) {
+		message := newPendingOutboxMessage(t)
+		outbox := &mockOutboxRepository{
+			fetchPendingFn: func(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+				return []*entities.OutboxMessage{message}, nil
+			},
+		}
+
+		var publishedQueue string
+		var published []byte
+		producer := &mockQueueProducer{
+			publishFn: func(ctx context.Context, queue string, body []byte) error {
+				publishedQueue, published = queue, body
+				return nil
+			},
+		}
+
+		relay := NewOutboxRelay(outbox, producer, &mockTxManager{}, &mockLogger{}, OutboxRelayConfig{})
+		err := relay.RelayPending(context.Background())
+
+		assertNoError(t, err)
+		if published == nil {
+			t.Fatal("expected event to be published")
+		}
+		if publishedQueue != ports.QueueProposals {
+			t.Errorf("expected queue %q, got %q", ports.QueueProposals, publishedQueue)
+		}
+		if string(published) != string(message.Payload) {
+			t.Error("published body doesn't match outbox payload")
+		}
+		if message.SentAt == nil {
+			t.Error("expected message to be marked as sent")
+		}
+		if len(outbox.updated) != 1 {
+			t.Errorf("expected 1 update, got %d", len(outbox.updated))
+		}
+	})
+
+	t.Run("should reschedule message with backoff when publishing fails", func(t *testing.T) {
+		message := newPendingOutboxMessage(t)
+		message.Attempts = 2
+		outbox := &mockOutboxRepository{
+			fetchPendingFn: func(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+				return []*entities.OutboxMessage{message}, nil
+			},
+		}
+		producer := &mockQueueProducer{
+			publishFn: func(ctx context.Context, queue string, body []byte) error {
+				return errors.New("queue unavailable")
+			},
+		}
+
+		relay := NewOutboxRelay(outbox, producer, &mockTxManager{}, &mockLogger{}, OutboxRelayConfig{
+			BaseBackoff: time.Second,
+			MaxBackoff:  time.Minute,
+		})
+		before := time.Now()
+		err := relay.RelayPending(context.Background())
+
+		assertNoError(t, err)
+		if message.SentAt != nil {
+			t.Error("expected message to stay pending")
+		}
+		if message.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", message.Attempts)
+		}
+		if message.LastError == "" {
+			t.Error("expected last error to be recorded")
+		}
+		if delay := message.NextAttemptAt.Sub(before); delay < 4*time.Second {
+			t.Errorf("expected backoff of at least 4s, got %v", delay)
+		}
+	})
+
+	t.Run("should return error when fetching pending messages fails", func(t *testing.T) {
+		outbox := &mockOutboxRepository{
+			fetchPendingFn: func(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		relay := NewOutboxRelay(outbox, &mockQueueProducer{}, &mockTxManager{}, &mockLogger{}, OutboxRelayConfig{})
+		err := relay.RelayPending(context.Background())
+
+		assertError(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+// maxConflictAttempts bounds how many times a change is tried when another
+// writer keeps updating the proposal first.
+const maxConflictAttempts = 3
+
+// retryOnConflict runs attempt again while it fails with
+// ErrProposalVersionConflict. attempt must read the proposal again each
+// time, since the copy it changed is stale.
+func retryOnConflict(ctx context.Context, logger ports.Logger, attempt func() error) error {
+	var err error
+	for i := 1; i <= maxConflictAttempts; i++ {
+		if err = attempt(); !errors.Is(err, domainErrors.ErrProposalVersionConflict) {
+			return err
+		}
+		logger.Warn(ctx, "proposal changed concurrently, retrying", "attempt", i)
+	}
+	return err
+}
+
+// ProposalTransitioner applies proposal status changes. Every change is
+// persisted together with its history entry, domain events, webhook
+// deliveries, live event and any extra effects, and the customer is notified
+// once it commits.
+type ProposalTransitioner struct {
+	repository ports.ProposalRepository
+	history    ports.StatusHistoryRepository
+	publisher  domainEventPublisher
+	webhooks   webhookEnqueuer
+	live       ports.ProposalEventPublisher
+	txManager  ports.TransactionManager
+	notifier   statusNotifier
+	logger     ports.Logger
+}
+
+func NewProposalTransitioner(
+	repo ports.ProposalRepository,
+	history ports.StatusHistoryRepository,
+	publisher domainEventPublisher,
+	webhooks webhookEnqueuer,
+	live ports.ProposalEventPublisher,
+	txManager ports.TransactionManager,
+	notifier statusNotifier,
+	logger ports.Logger,
+) *ProposalTransitioner {
+	return &ProposalTransitioner{
+		repository: repo,
+		history:    history,
+		publisher:  publisher,
+		webhooks:   webhooks,
+		live:       live,
+		txManager:  txManager,
+		notifier:   notifier,
+		logger:     logger,
+	}
+}
+
+// Transition runs apply and persists the result. eventType and messageID
+// identify what triggered the change in the status history.
+func (t *ProposalTransitioner) Transition(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	eventType string,
+	messageID string,
+	apply func() error,
+	effects ...func(ctx context.Context) error,
+) error {
+	previous := proposal.Status
+	if err := apply(); err != nil {
+		t.logger.Error(ctx, "failed to change proposal status", "proposal_id", proposal.ID, "status", previous, "error", err)
+		return err
+	}
+
+	entry := entities.NewStatusHistoryEntry(proposal.ID, previous, proposal.Status, eventType, messageID)
+	err := t.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := t.repository.Update(ctx, proposal); err != nil {
+			return err
+		}
+		if err := t.history.Save(ctx, entry); err != nil {
+			return err
+		}
+		if err := t.publisher.Publish(ctx, proposal.PullEvents()...); err != nil {
+			return err
+		}
+		if err := t.webhooks.Enqueue(ctx, proposal, previous); err != nil {
+			return err
+		}
+		for _, effect := range effects {
+			if err := effect(ctx); err != nil {
+				return err
+			}
+		}
+		return t.live.Publish(ctx, newProposalEvent(proposal, eventType))
+	})
+	if err != nil {
+		t.logger.Error(ctx, "failed to update proposal", "error", err)
+		return err
+	}
+
+	t.notifier.NotifyStatus(ctx, proposal)
+	return nil
+}
+
+// PublishLive sends a live event that does not change the proposal status.
+// Failures are only logged.
+func (t *ProposalTransitioner) PublishLive(ctx context.Context, proposal *entities.Proposal, eventType string) {
+	if err := t.live.Publish(ctx, newProposalEvent(proposal, eventType)); err != nil {
+		t.logger.Warn(ctx, "failed to publish proposal event", "proposal_id", proposal.ID, "error", err)
+	}
+}
+
+func newProposalEvent(proposal *entities.Proposal, eventType string) *ports.ProposalEvent {
+	return &ports.ProposalEvent{
+		ProposalID: proposal.ID,
+		EventType:  eventType,
+		Status:     string(proposal.Status),
+		Final:      proposal.IsFinalized(),
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+// RespondToOfferUseCase records the customer's answer to an approved offer.
+// Accepting opens the account in the same transaction as the status change.
+type RespondToOfferUseCase struct {
+	repository  ports.ProposalRepository
+	transitions *ProposalTransitioner
+	evidence    ports.OfferEvidenceRepository
+	accounts    accountIssuer
+	logger      ports.Logger
+}
+
+func NewRespondToOfferUseCase(
+	repo ports.ProposalRepository,
+	transitions *ProposalTransitioner,
+	evidence ports.OfferEvidenceRepository,
+	accounts accountIssuer,
+	logger ports.Logger,
+) *RespondToOfferUseCase {
+	return &RespondToOfferUseCase{
+		repository:  repo,
+		transitions: transitions,
+		evidence:    evidence,
+		accounts:    accounts,
+		logger:      logger,
+	}
+}
+
+func (uc *RespondToOfferUseCase) Accept(
+	ctx context.Context,
+	id uuid.UUID,
+	req *dto.OfferDecisionRequest,
+) (*dto.ProposalResponse, error) {
+	proposal, err := uc.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	accept := func() error { return proposal.AcceptOffer(req.TermsVersion) }
+	issueAccount := func(ctx context.Context) error { return uc.accounts.Issue(ctx, proposal) }
+	err = uc.respond(ctx, proposal, entities.OfferDecisionAccepted, events.EventProposalOfferAccepted, req, accept, issueAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx, "offer accepted", "proposal_id", proposal.ID, "terms_version", req.TermsVersion)
+	return entityToResponse(proposal), nil
+}
+
+func (uc *RespondToOfferUseCase) Decline(
+	ctx context.Context,
+	id uuid.UUID,
+	req *dto.OfferDecisionRequest,
+) (*dto.ProposalResponse, error) {
+	proposal, err := uc.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.respond(ctx, proposal, entities.OfferDecisionDeclined, events.EventProposalOfferDeclined, req, proposal.DeclineOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx, "offer declined", "proposal_id", proposal.ID)
+	return entityToResponse(proposal), nil
+}
+
+func (uc *RespondToOfferUseCase) find(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+	proposal, err := uc.repository.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+	return proposal, nil
+}
+
+// respond applies the decision and saves its evidence. An offer found past
+// its deadline is expired on the spot.
+func (uc *RespondToOfferUseCase) respond(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	decision entities.OfferDecision,
+	eventType string,
+	req *dto.OfferDecisionRequest,
+	apply func() error,
+	effects ...func(ctx context.Context) error,
+) error {
+	evidence := entities.NewOfferEvidence(proposal.ID, decision, req.TermsVersion, req.IPAddress, req.ForwardedFor, req.UserAgent)
+	saveEvidence := func(ctx context.Context) error { return uc.evidence.Save(ctx, evidence) }
+
+	err := uc.transitions.Transition(ctx, proposal, eventType, "", apply, append(effects, saveEvidence)...)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domainErrors.ErrOfferExpired):
+		if err := uc.transitions.Transition(ctx, proposal, events.EventProposalOfferExpired, "", proposal.ExpireOffer); err != nil {
+			return appErrors.NewInternalError("failed to expire offer", err)
+		}
+		return appErrors.NewConflictError("OFFER_EXPIRED", domainErrors.ErrOfferExpired)
+	case errors.Is(err, domainErrors.ErrOnlyOfferPendingCanBeDecided):
+		return appErrors.NewConflictError("OFFER_NOT_PENDING", err)
+	case errors.Is(err, domainErrors.ErrTermsVersionMismatch):
+		return appErrors.NewConflictError("TERMS_VERSION_MISMATCH", err)
+	case errors.Is(err, domainErrors.ErrTermsVersionRequired):
+		return appErrors.NewInvalidInputError(err)
+	case errors.Is(err, domainErrors.ErrProposalVersionConflict):
+		return appErrors.NewConflictError("PROPOSAL_VERSION_CONFLICT", err)
+	default:
+		return appErrors.NewInternalError("failed to respond to offer", err)
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/google/uuid"
+)
+
+func newOfferPendingProposal(expiresAt time.Time) *entities.Proposal {
+	proposal := newProposalWithStatus(entities.StatusOfferPending)
+	proposal.Offer = &entities.CreditOffer{CreditLimit: money.MustParse("3000"), RiskTier: "HIGH", TermsVersion: "v1", ExpiresAt: expiresAt}
+	return proposal
+}
+
+func TestRespondToOfferUseCase(t *testing.T) {
+	type fixture struct {
+		uc       *RespondToOfferUseCase
+		repo     *mockRepository
+		evidence *mockOfferEvidenceRepository
+		accounts *mockAccountIssuer
+		history  *mockStatusHistoryRepository
+	}
+	setup := func(proposal *entities.Proposal) fixture {
+		f := fixture{
+			repo: &mockRepository{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+					if proposal == nil {
+						return nil, domainErrors.ErrProposalNotFound
+					}
+					return proposal, nil
+				},
+			},
+			evidence: &mockOfferEvidenceRepository{},
+			accounts: &mockAccountIssuer{},
+			history:  &mockStatusHistoryRepository{},
+		}
+		transitions := NewProposalTransitioner(f.repo, f.history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{})
+		f.uc = NewRespondToOfferUseCase(f.repo, transitions, f.evidence, f.accounts, &mockLogger{})
+		return f
+	}
+	request := &dto.OfferDecisionRequest{TermsVersion: "v1", IPAddress: "10.0.0.2", ForwardedFor: "203.0.113.7", UserAgent: "test-agent"}
+
+	t.Run("should accept offer, open account and record evidence", func(t *testing.T) {
+		proposal := newOfferPendingProposal(time.Now().Add(time.Hour))
+		f := setup(proposal)
+
+		response, err := f.uc.Accept(context.Background(), proposal.ID, request)
+
+		assertNoError(t, err)
+		if response.Status != string(entities.StatusAccepted) {
+			t.Errorf("expected status accepted, got %q", response.Status)
+		}
+		if len(f.accounts.issued) != 1 {
+			t.Errorf("expected account issued, got %d", len(f.accounts.issued))
+		}
+		if len(f.evidence.saved) != 1 {
+			t.Fatalf("expected 1 evidence, got %d", len(f.evidence.saved))
+		}
+		evidence := f.evidence.saved[0]
+		if evidence.Decision != entities.OfferDecisionAccepted || evidence.TermsVersion != "v1" || evidence.IPAddress != "10.0.0.2" || evidence.ForwardedFor != "203.0.113.7" {
+			t.Errorf("unexpected evidence %+v", evidence)
+		}
+	})
+
+	t.Run("should decline offer without opening account", func(t *testing.T) {
+		proposal := newOfferPendingProposal(time.Now().Add(time.Hour))
+		f := setup(proposal)
+
+		response, err := f.uc.Decline(context.Background(), proposal.ID, &dto.OfferDecisionRequest{IPAddress: "203.0.113.7"})
+
+		assertNoError(t, err)
+		if response.Status != string(entities.StatusDeclined) {
+			t.Errorf("expected status declined, got %q", response.Status)
+		}
+		if len(f.accounts.issued) != 0 {
+			t.Errorf("expected no account, got %d", len(f.accounts.issued))
+		}
+		if len(f.evidence.saved) != 1 || f.evidence.saved[0].Decision != entities.OfferDecisionDeclined {
+			t.Errorf("expected declined evidence, got %+v", f.evidence.saved)
+		}
+	})
+
+	t.Run("should expire offer and return 409 when accepting after deadline", func(t *testing.T) {
+		proposal := newOfferPendingProposal(time.Now().Add(-time.Minute))
+		f := setup(proposal)
+
+		_, err := f.uc.Accept(context.Background(), proposal.ID, request)
+
+		assertApplicationError(t, err, "OFFER_EXPIRED", 409)
+		if proposal.Status != entities.StatusOfferExpired {
+			t.Errorf("expected status offer_expired, got %q", proposal.Status)
+		}
+		if len(f.accounts.issued) != 0 || len(f.evidence.saved) != 0 {
+			t.Error("expected no account and no evidence for expired offer")
+		}
+	})
+
+	t.Run("should return 409 when terms version does not match", func(t *testing.T) {
+		proposal := newOfferPendingProposal(time.Now().Add(time.Hour))
+		f := setup(proposal)
+
+		_, err := f.uc.Accept(context.Background(), proposal.ID, &dto.OfferDecisionRequest{TermsVersion: "v0"})
+
+		assertApplicationError(t, err, "TERMS_VERSION_MISMATCH", 409)
+		if len(f.repo.updated) != 0 {
+			t.Error("expected no update")
+		}
+	})
+
+	t.Run("should return 400 when terms version is missing", func(t *testing.T) {
+		proposal := newOfferPendingProposal(time.Now().Add(time.Hour))
+		f := setup(proposal)
+
+		_, err := f.uc.Accept(context.Background(), proposal.ID, &dto.OfferDecisionRequest{})
+
+		assertApplicationError(t, err, "INVALID_INPUT", 400)
+	})
+
+	t.Run("should return 409 when offer is not pending", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAccepted)
+		f := setup(proposal)
+
+		_, err := f.uc.Decline(context.Background(), proposal.ID, request)
+
+		assertApplicationError(t, err, "OFFER_NOT_PENDING", 409)
+	})
+
+	t.Run("should return 404 when proposal is not found", func(t *testing.T) {
+		f := setup(nil)
+
+		_, err := f.uc.Accept(context.Background(), uuid.New(), request)
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+}
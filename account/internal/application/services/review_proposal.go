@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+const ReasonManualReviewRejected = "MANUAL_REVIEW_REJECTED"
+
+// ReviewProposalUseCase records a back-office operator's decision on a
+// proposal under manual review. The decision is saved in the same
+// transaction as the status change.
+type ReviewProposalUseCase struct {
+	repository  ports.ProposalRepository
+	transitions *ProposalTransitioner
+	decisions   ports.ReviewDecisionRepository
+	logger      ports.Logger
+	cfg         OfferConfig
+}
+
+func NewReviewProposalUseCase(
+	repo ports.ProposalRepository,
+	transitions *ProposalTransitioner,
+	decisions ports.ReviewDecisionRepository,
+	logger ports.Logger,
+	cfg OfferConfig,
+) *ReviewProposalUseCase {
+	return &ReviewProposalUseCase{
+		repository:  repo,
+		transitions: transitions,
+		decisions:   decisions,
+		logger:      logger,
+		cfg:         cfg.withDefaults(),
+	}
+}
+
+// Approve presents the offer computed by risk-analysis to the customer.
+func (uc *ReviewProposalUseCase) Approve(
+	ctx context.Context,
+	id uuid.UUID,
+	req *dto.ReviewDecisionRequest,
+) (*dto.ProposalResponse, error) {
+	proposal, err := uc.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var offer entities.CreditOffer
+	if proposal.Offer != nil {
+		offer = uc.cfg.present(*proposal.Offer, time.Now())
+	}
+	approve := func() error { return proposal.ApproveReview(offer) }
+	if err := uc.decide(ctx, proposal, entities.ReviewOutcomeApproved, req, approve); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx, "manual review approved", "proposal_id", proposal.ID, "operator", req.Operator)
+	return entityToResponse(proposal), nil
+}
+
+func (uc *ReviewProposalUseCase) Reject(
+	ctx context.Context,
+	id uuid.UUID,
+	req *dto.ReviewDecisionRequest,
+) (*dto.ProposalResponse, error) {
+	proposal, err := uc.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := entities.RejectionReason{
+		Code:    ReasonManualReviewRejected,
+		Message: "proposal rejected after manual review",
+	}
+	reject := func() error { return proposal.RejectReview(reason) }
+	if err := uc.decide(ctx, proposal, entities.ReviewOutcomeRejected, req, reject); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx, "manual review rejected", "proposal_id", proposal.ID, "operator", req.Operator)
+	return entityToResponse(proposal), nil
+}
+
+func (uc *ReviewProposalUseCase) find(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+	proposal, err := uc.repository.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+	return proposal, nil
+}
+
+func (uc *ReviewProposalUseCase) decide(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	outcome entities.ReviewOutcome,
+	req *dto.ReviewDecisionRequest,
+	apply func() error,
+) error {
+	decision, err := entities.NewReviewDecision(proposal.ID, outcome, req.Operator, req.Notes)
+	if err != nil {
+		return appErrors.NewInvalidInputError(err)
+	}
+	saveDecision := func(ctx context.Context) error { return uc.decisions.Save(ctx, decision) }
+
+	eventType := events.EventProposalApproved
+	if outcome == entities.ReviewOutcomeRejected {
+		eventType = events.EventProposalRejected
+	}
+
+	err = uc.transitions.Transition(ctx, proposal, eventType, "", apply, saveDecision)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domainErrors.ErrOnlyUnderReviewCanBeDecided):
+		return appErrors.NewConflictError("REVIEW_NOT_PENDING", err)
+	case errors.Is(err, domainErrors.ErrProposalVersionConflict):
+		return appErrors.NewConflictError("PROPOSAL_VERSION_CONFLICT", err)
+	default:
+		return appErrors.NewInternalError("failed to record review decision", err)
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/google/uuid"
+)
+
+func TestReviewProposalUseCase(t *testing.T) {
+	setup := func(proposal *entities.Proposal) (*ReviewProposalUseCase, *mockRepository, *mockReviewDecisionRepository) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				if proposal == nil {
+					return nil, domainErrors.ErrProposalNotFound
+				}
+				return proposal, nil
+			},
+		}
+		decisions := &mockReviewDecisionRepository{}
+		transitions := NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{})
+		return NewReviewProposalUseCase(repo, transitions, decisions, &mockLogger{}, OfferConfig{AnnualFee: money.MustParse("120")}), repo, decisions
+	}
+	underReview := func() *entities.Proposal {
+		proposal := newProposalWithStatus(entities.StatusUnderReview)
+		proposal.Review = &entities.ReviewReason{Code: "SALARY_BORDERLINE"}
+		proposal.Offer = &entities.CreditOffer{CreditLimit: money.MustParse("1600"), RiskTier: "HIGH", RiskScore: 358}
+		return proposal
+	}
+	request := &dto.ReviewDecisionRequest{Notes: "income confirmed by payslip", Operator: "op-42"}
+
+	t.Run("should approve and present the offer with terms", func(t *testing.T) {
+		proposal := underReview()
+		uc, repo, decisions := setup(proposal)
+
+		response, err := uc.Approve(context.Background(), proposal.ID, request)
+
+		assertNoError(t, err)
+		if response.Status != string(entities.StatusOfferPending) {
+			t.Errorf("expected offer_pending, got %q", response.Status)
+		}
+		offer := repo.updated[0].Offer
+		if offer == nil || offer.CreditLimit != money.MustParse("1600") || offer.AnnualFee != money.MustParse("120") || offer.TermsVersion != DefaultOfferTermsVersion || offer.ExpiresAt.IsZero() {
+			t.Errorf("expected offer with terms and expiry, got %+v", offer)
+		}
+		if len(decisions.saved) != 1 {
+			t.Fatalf("expected 1 decision, got %d", len(decisions.saved))
+		}
+		if got := decisions.saved[0]; got.Outcome != entities.ReviewOutcomeApproved || got.Operator != "op-42" || got.Notes != request.Notes {
+			t.Errorf("unexpected decision %+v", got)
+		}
+	})
+
+	t.Run("should reject and drop the offer", func(t *testing.T) {
+		proposal := underReview()
+		uc, _, decisions := setup(proposal)
+
+		response, err := uc.Reject(context.Background(), proposal.ID, request)
+
+		assertNoError(t, err)
+		if response.Status != string(entities.StatusRejected) || response.Offer != nil {
+			t.Errorf("expected rejected without offer, got %q %+v", response.Status, response.Offer)
+		}
+		if response.Rejection == nil || response.Rejection.Code != ReasonManualReviewRejected {
+			t.Errorf("expected rejection %q, got %+v", ReasonManualReviewRejected, response.Rejection)
+		}
+		if len(decisions.saved) != 1 || decisions.saved[0].Outcome != entities.ReviewOutcomeRejected {
+			t.Errorf("expected a rejected decision, got %+v", decisions.saved)
+		}
+	})
+
+	t.Run("should require notes and operator", func(t *testing.T) {
+		for _, req := range []*dto.ReviewDecisionRequest{
+			{Operator: "op-42"},
+			{Notes: "ok"},
+		} {
+			proposal := underReview()
+			uc, repo, decisions := setup(proposal)
+
+			_, err := uc.Approve(context.Background(), proposal.ID, req)
+
+			assertApplicationError(t, err, "INVALID_INPUT", 400)
+			if len(repo.updated) != 0 || len(decisions.saved) != 0 {
+				t.Error("expected nothing saved")
+			}
+		}
+	})
+
+	t.Run("should return conflict when proposal is not under review", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusOfferPending)
+		uc, _, decisions := setup(proposal)
+
+		_, err := uc.Reject(context.Background(), proposal.ID, request)
+
+		assertApplicationError(t, err, "REVIEW_NOT_PENDING", 409)
+		if len(decisions.saved) != 0 {
+			t.Error("expected no decision saved")
+		}
+	})
+
+	t.Run("should return not found when proposal does not exist", func(t *testing.T) {
+		uc, _, _ := setup(nil)
+
+		_, err := uc.Approve(context.Background(), uuid.New(), request)
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
@@ -9,17 +10,23 @@ import (
 )
 
 type ProposalStatusChangedEventHandler struct {
-	repository ports.ProposalRepository
-	logger     ports.Logger
+	repository  ports.ProposalRepository
+	transitions *ProposalTransitioner
+	logger      ports.Logger
+	cfg         OfferConfig
 }
 
 func NewProposalStatusChangedEventHandler(
 	repo ports.ProposalRepository,
+	transitions *ProposalTransitioner,
 	logger ports.Logger,
+	cfg OfferConfig,
 ) *ProposalStatusChangedEventHandler {
 	return &ProposalStatusChangedEventHandler{
-		repository: repo,
-		logger:     logger,
+		repository:  repo,
+		transitions: transitions,
+		logger:      logger,
+		cfg:         cfg.withDefaults(),
 	}
 }
 
@@ -29,37 +36,71 @@ func (h *ProposalStatusChangedEventHandler) Handle(
 ) error {
 	h.logger.Info(ctx, "processing risk analysis event", "event_type", event.EventType, "proposal_id", event.ProposalID)
 
+	// Another event of the same proposal may be processed concurrently by
+	// another poller. The loser reads the proposal again and reapplies; when
+	// the winner already moved the proposal past it, the event is stale and
+	// is acknowledged without effect.
+	return retryOnConflict(ctx, h.logger, func() error {
+		return h.handle(ctx, event)
+	})
+}
+
+func (h *ProposalStatusChangedEventHandler) handle(
+	ctx context.Context,
+	event *events.ProposalStatusChangedEvent,
+) error {
 	proposal, err := h.repository.FindByID(ctx, event.ProposalID)
 	if err != nil {
 		h.logger.Error(ctx, "proposal not found", "proposal_id", event.ProposalID, "error", err)
 		return err
 	}
 
+	// The analysis keeps running after the customer cancels, may answer after
+	// the proposal expired, and answers twice when the stuck proposal detector
+	// requested it again. Events the proposal already moved past are
+	// acknowledged without effect so the consumer does not retry them.
+	if !eventApplies(event, proposal) {
+		h.logger.Info(ctx, "ignoring stale risk analysis event", "event_type", event.EventType, "proposal_id", event.ProposalID, "status", proposal.Status)
+		return nil
+	}
+
 	switch event.EventType {
 	case events.EventDocumentsApproved:
-		return h.handleAnalyzing(ctx, proposal)
+		return h.handleAnalyzing(ctx, proposal, event)
 	case events.EventDocumentsRejected, events.EventCreditRejected, events.EventFraudRejected:
-		return h.handleRejection(ctx, proposal)
+		return h.handleRejection(ctx, proposal, event)
 	case events.EventRiskAnalysisCompleted:
 		return h.handleCompletion(ctx, proposal, event)
+	case events.EventManualReviewRequired:
+		return h.handleReview(ctx, proposal, event)
 	default:
 		h.logger.Info(ctx, "intermediate event received", "event_type", event.EventType)
+		h.transitions.PublishLive(ctx, proposal, event.EventType)
 		return nil
 	}
 }
 
-func (h *ProposalStatusChangedEventHandler) handleAnalyzing(ctx context.Context, proposal *entities.Proposal) error {
-	if !proposal.IsPending() {
-		return nil
-	}
-
-	if err := proposal.StartAnalysis(); err != nil {
-		h.logger.Error(ctx, "failed to start analysis", "error", err)
-		return err
+// eventApplies reports whether the proposal is still in a status the event
+// moves it from. Rejections and intermediate events apply while the
+// analysis is open.
+func eventApplies(event *events.ProposalStatusChangedEvent, proposal *entities.Proposal) bool {
+	switch {
+	case event.EventType == events.EventDocumentsApproved:
+		return proposal.IsPending()
+	case event.EventType == events.EventManualReviewRequired,
+		event.EventType == events.EventRiskAnalysisCompleted && event.Approved:
+		return proposal.IsAnalyzing()
+	default:
+		return proposal.IsPending() || proposal.IsAnalyzing()
 	}
+}
 
-	if err := h.repository.Update(ctx, proposal); err != nil {
-		h.logger.Error(ctx, "failed to update proposal", "error", err)
+func (h *ProposalStatusChangedEventHandler) handleAnalyzing(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	event *events.ProposalStatusChangedEvent,
+) error {
+	if err := h.transition(ctx, proposal, event, proposal.StartAnalysis); err != nil {
 		return err
 	}
 
@@ -67,40 +108,73 @@ func (h *ProposalStatusChangedEventHandler) handleAnalyzing(ctx context.Context,
 	return nil
 }
 
-func (h *ProposalStatusChangedEventHandler) handleRejection(ctx context.Context, proposal *entities.Proposal) error {
-	if err := proposal.Reject(); err != nil {
-		h.logger.Error(ctx, "failed to reject proposal", "error", err)
-		return err
-	}
-
-	if err := h.repository.Update(ctx, proposal); err != nil {
-		h.logger.Error(ctx, "failed to update proposal", "error", err)
+func (h *ProposalStatusChangedEventHandler) handleRejection(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	event *events.ProposalStatusChangedEvent,
+) error {
+	reason := entities.RejectionReason{Code: event.ReasonCode, Message: event.ReasonMessage}
+	reject := func() error { return proposal.RejectWithReason(reason) }
+	if err := h.transition(ctx, proposal, event, reject); err != nil {
 		return err
 	}
 
-	h.logger.Info(ctx, "proposal rejected", "proposal_id", proposal.ID.String())
+	h.logger.Info(ctx, "proposal rejected", "proposal_id", proposal.ID.String(), "reason_code", reason.Code)
 	return nil
 }
 
+// handleCompletion presents the offer to the customer. The account is only
+// opened once the offer is accepted.
 func (h *ProposalStatusChangedEventHandler) handleCompletion(
 	ctx context.Context,
 	proposal *entities.Proposal,
 	event *events.ProposalStatusChangedEvent,
 ) error {
 	if !event.Approved {
-		return h.handleRejection(ctx, proposal)
+		return h.handleRejection(ctx, proposal, event)
 	}
 
-	if err := proposal.Approve(); err != nil {
-		h.logger.Error(ctx, "failed to approve proposal", "error", err)
+	offer := h.cfg.present(offerFromEvent(event), time.Now())
+	approve := func() error { return proposal.ApproveWithOffer(offer) }
+	if err := h.transition(ctx, proposal, event, approve); err != nil {
 		return err
 	}
 
-	if err := h.repository.Update(ctx, proposal); err != nil {
-		h.logger.Error(ctx, "failed to update proposal", "error", err)
+	h.logger.Info(ctx, "proposal approved, offer pending", "proposal_id", proposal.ID.String(),
+		"credit_limit", offer.CreditLimit, "risk_tier", offer.RiskTier)
+	return nil
+}
+
+// handleReview parks the proposal for an operator. The card terms are only
+// attached once the operator approves, so the offer deadline starts then.
+func (h *ProposalStatusChangedEventHandler) handleReview(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	event *events.ProposalStatusChangedEvent,
+) error {
+	reason := entities.ReviewReason{Code: event.ReasonCode, Message: event.ReasonMessage}
+	review := func() error { return proposal.SendToReview(reason, offerFromEvent(event)) }
+	if err := h.transition(ctx, proposal, event, review); err != nil {
 		return err
 	}
 
-	h.logger.Info(ctx, "proposal approved", "proposal_id", proposal.ID.String())
+	h.logger.Info(ctx, "proposal sent to manual review", "proposal_id", proposal.ID.String(), "reason_code", reason.Code)
 	return nil
 }
+
+func offerFromEvent(event *events.ProposalStatusChangedEvent) entities.CreditOffer {
+	return entities.CreditOffer{
+		CreditLimit: event.CreditLimit,
+		RiskTier:    event.RiskTier,
+		RiskScore:   event.RiskScore,
+	}
+}
+
+func (h *ProposalStatusChangedEventHandler) transition(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	event *events.ProposalStatusChangedEvent,
+	apply func() error,
+) error {
+	return h.transitions.Transition(ctx, proposal, event.EventType, event.MessageID, apply)
+}
@@ -0,0 +1,473 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/google/uuid"
+)
+
+func newProposalWithStatus(status entities.ProposalStatus) *entities.Proposal {
+	return &entities.Proposal{
+		ID:       uuid.New(),
+		FullName: "John Doe",
+		CPF:      "12345678909",
+		Status:   status,
+	}
+}
+
+func newStatusChangedEvent(eventType string, proposalID uuid.UUID, approved bool) *events.ProposalStatusChangedEvent {
+	return &events.ProposalStatusChangedEvent{
+		EventType:  eventType,
+		ProposalID: proposalID,
+		Approved:   approved,
+		MessageID:  "msg-1",
+	}
+}
+
+func TestProposalStatusChangedEventHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     entities.ProposalStatus
+		eventType  string
+		approved   bool
+		wantStatus entities.ProposalStatus
+		wantUpdate bool
+	}{
+		{
+			name:       "documents approved starts analysis",
+			status:     entities.StatusPending,
+			eventType:  events.EventDocumentsApproved,
+			approved:   true,
+			wantStatus: entities.StatusAnalyzing,
+			wantUpdate: true,
+		},
+		{
+			name:       "documents approved is ignored when already analyzing",
+			status:     entities.StatusAnalyzing,
+			eventType:  events.EventDocumentsApproved,
+			approved:   true,
+			wantStatus: entities.StatusAnalyzing,
+		},
+		{
+			name:       "documents rejected rejects pending proposal",
+			status:     entities.StatusPending,
+			eventType:  events.EventDocumentsRejected,
+			wantStatus: entities.StatusRejected,
+			wantUpdate: true,
+		},
+		{
+			name:       "fraud rejected rejects analyzing proposal",
+			status:     entities.StatusAnalyzing,
+			eventType:  events.EventFraudRejected,
+			wantStatus: entities.StatusRejected,
+			wantUpdate: true,
+		},
+		{
+			name:       "risk analysis completed presents offer",
+			status:     entities.StatusAnalyzing,
+			eventType:  events.EventRiskAnalysisCompleted,
+			approved:   true,
+			wantStatus: entities.StatusOfferPending,
+			wantUpdate: true,
+		},
+		{
+			name:       "manual review required sends proposal to review",
+			status:     entities.StatusAnalyzing,
+			eventType:  events.EventManualReviewRequired,
+			wantStatus: entities.StatusUnderReview,
+			wantUpdate: true,
+		},
+		{
+			name:       "risk analysis completed is ignored when cancelled",
+			status:     entities.StatusCancelled,
+			eventType:  events.EventRiskAnalysisCompleted,
+			approved:   true,
+			wantStatus: entities.StatusCancelled,
+		},
+		{
+			name:       "rejection is ignored when cancelled",
+			status:     entities.StatusCancelled,
+			eventType:  events.EventCreditRejected,
+			wantStatus: entities.StatusCancelled,
+		},
+		{
+			name:       "late risk analysis completed is ignored when expired",
+			status:     entities.StatusExpired,
+			eventType:  events.EventRiskAnalysisCompleted,
+			approved:   true,
+			wantStatus: entities.StatusExpired,
+		},
+		{
+			name:       "duplicate risk analysis completed is ignored once the offer is pending",
+			status:     entities.StatusOfferPending,
+			eventType:  events.EventRiskAnalysisCompleted,
+			approved:   true,
+			wantStatus: entities.StatusOfferPending,
+		},
+		{
+			name:       "duplicate rejection is ignored once rejected",
+			status:     entities.StatusRejected,
+			eventType:  events.EventCreditRejected,
+			wantStatus: entities.StatusRejected,
+		},
+		{
+			name:       "duplicate manual review required is ignored once under review",
+			status:     entities.StatusUnderReview,
+			eventType:  events.EventManualReviewRequired,
+			wantStatus: entities.StatusUnderReview,
+		},
+		{
+			name:       "risk analysis completed is ignored before documents are approved",
+			status:     entities.StatusPending,
+			eventType:  events.EventRiskAnalysisCompleted,
+			approved:   true,
+			wantStatus: entities.StatusPending,
+		},
+		{
+			name:       "intermediate event keeps status",
+			status:     entities.StatusAnalyzing,
+			eventType:  events.EventCreditApproved,
+			approved:   true,
+			wantStatus: entities.StatusAnalyzing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposal := newProposalWithStatus(tt.status)
+			repo := &mockRepository{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+					return proposal, nil
+				},
+			}
+			history := &mockStatusHistoryRepository{}
+			domainEvents := &mockDomainEventPublisher{}
+			webhooks := &mockWebhookEnqueuer{}
+			publisher := &mockProposalEventBroker{}
+			notifier := &mockNotifier{}
+			handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, history, domainEvents, webhooks, publisher, &mockTxManager{}, notifier, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+			err := handler.Handle(context.Background(), newStatusChangedEvent(tt.eventType, proposal.ID, tt.approved))
+
+			assertNoError(t, err)
+			if proposal.Status != tt.wantStatus {
+				t.Errorf("expected status %q, got %q", tt.wantStatus, proposal.Status)
+			}
+			if tt.wantUpdate != (len(repo.updated) == 1) {
+				t.Errorf("expected update=%v, got %d updates", tt.wantUpdate, len(repo.updated))
+			}
+			if tt.wantUpdate != (len(history.saved) == 1) {
+				t.Errorf("expected history entry=%v, got %d entries", tt.wantUpdate, len(history.saved))
+			}
+			if tt.wantUpdate != (len(domainEvents.published) > 0) {
+				t.Errorf("expected domain events=%v, got %d", tt.wantUpdate, len(domainEvents.published))
+			}
+			if tt.wantUpdate != (len(webhooks.enqueued) == 1) {
+				t.Errorf("expected webhook=%v, got %v", tt.wantUpdate, webhooks.enqueued)
+			}
+			if tt.wantUpdate && len(publisher.published) != 1 {
+				t.Errorf("expected 1 live event, got %d", len(publisher.published))
+			}
+			if tt.wantUpdate != (len(notifier.notified) == 1) {
+				t.Errorf("expected notification=%v, got %v", tt.wantUpdate, notifier.notified)
+			}
+		})
+	}
+
+	t.Run("should publish intermediate events as live updates", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		publisher := &mockProposalEventBroker{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, publisher, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventCreditApproved, proposal.ID, true))
+
+		assertNoError(t, err)
+		if len(publisher.published) != 1 {
+			t.Fatalf("expected 1 live event, got %d", len(publisher.published))
+		}
+		if got := publisher.published[0]; got.EventType != events.EventCreditApproved || got.Final {
+			t.Errorf("unexpected live event %+v", got)
+		}
+	})
+
+	t.Run("should record transition in history", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		history := &mockStatusHistoryRepository{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventDocumentsApproved, proposal.ID, true))
+
+		assertNoError(t, err)
+		if len(history.saved) != 1 {
+			t.Fatalf("expected 1 history entry, got %d", len(history.saved))
+		}
+		entry := history.saved[0]
+		if entry.PreviousStatus != entities.StatusPending || entry.NewStatus != entities.StatusAnalyzing {
+			t.Errorf("expected pending -> analyzing, got %q -> %q", entry.PreviousStatus, entry.NewStatus)
+		}
+		if entry.EventType != events.EventDocumentsApproved {
+			t.Errorf("expected event type %q, got %q", events.EventDocumentsApproved, entry.EventType)
+		}
+		if entry.MessageID != "msg-1" {
+			t.Errorf("expected message id msg-1, got %q", entry.MessageID)
+		}
+	})
+
+	t.Run("should persist rejection reason from event", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		event := newStatusChangedEvent(events.EventCreditRejected, proposal.ID, false)
+		event.ReasonCode = "SALARY_BELOW_MINIMUM"
+		event.ReasonMessage = "salary must be greater than 3000"
+		err := handler.Handle(context.Background(), event)
+
+		assertNoError(t, err)
+		if len(repo.updated) != 1 {
+			t.Fatalf("expected 1 update, got %d", len(repo.updated))
+		}
+		rejection := repo.updated[0].Rejection
+		if rejection == nil || rejection.Code != event.ReasonCode || rejection.Message != event.ReasonMessage {
+			t.Errorf("expected rejection %q/%q, got %+v", event.ReasonCode, event.ReasonMessage, rejection)
+		}
+	})
+
+	t.Run("should persist credit offer from event", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		event := newStatusChangedEvent(events.EventRiskAnalysisCompleted, proposal.ID, true)
+		event.CreditLimit = money.MustParse("3000")
+		event.RiskTier = "HIGH"
+		event.RiskScore = 400
+		err := handler.Handle(context.Background(), event)
+
+		assertNoError(t, err)
+		if len(repo.updated) != 1 {
+			t.Fatalf("expected 1 update, got %d", len(repo.updated))
+		}
+		offer := repo.updated[0].Offer
+		if offer == nil || offer.CreditLimit != money.MustParse("3000") || offer.RiskTier != "HIGH" || offer.RiskScore != 400 {
+			t.Fatalf("expected offer 3000/HIGH/400, got %+v", offer)
+		}
+		if offer.TermsVersion != DefaultOfferTermsVersion || offer.ExpiresAt.IsZero() {
+			t.Errorf("expected default terms and an expiry, got %+v", offer)
+		}
+	})
+
+	t.Run("should return error when proposal is not found", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return nil, errors.New("not found")
+			},
+		}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventDocumentsApproved, uuid.New(), true))
+
+		assertError(t, err)
+	})
+
+	t.Run("should acknowledge a duplicate RiskAnalysisCompleted from a re-published analysis", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		history := &mockStatusHistoryRepository{}
+		notifier := &mockNotifier{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, notifier, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		event := newStatusChangedEvent(events.EventRiskAnalysisCompleted, proposal.ID, true)
+		event.CreditLimit = money.MustParse("3000")
+		assertNoError(t, handler.Handle(context.Background(), event))
+
+		duplicate := newStatusChangedEvent(events.EventRiskAnalysisCompleted, proposal.ID, true)
+		duplicate.MessageID = "msg-2"
+		duplicate.CreditLimit = money.MustParse("4000")
+		err := handler.Handle(context.Background(), duplicate)
+
+		assertNoError(t, err)
+		if proposal.Status != entities.StatusOfferPending || proposal.Offer.CreditLimit != money.MustParse("3000") {
+			t.Errorf("expected the first offer kept, got %q with %+v", proposal.Status, proposal.Offer)
+		}
+		if len(repo.updated) != 1 || len(history.saved) != 1 || len(notifier.notified) != 1 {
+			t.Errorf("expected the duplicate to have no effect, got %d updates, %d history entries, %d notifications",
+				len(repo.updated), len(history.saved), len(notifier.notified))
+		}
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+			updateFn: func(ctx context.Context, p *entities.Proposal) error {
+				return errors.New("database error")
+			},
+		}
+		history := &mockStatusHistoryRepository{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventFraudRejected, proposal.ID, false))
+
+		assertError(t, err)
+		if len(history.saved) != 0 {
+			t.Error("expected no history entry when update fails")
+		}
+	})
+	t.Run("should reread the proposal and retry on a version conflict", func(t *testing.T) {
+		// The first read is stale: a concurrent FraudRejected already moved
+		// the proposal out of analyzing.
+		stale := newProposalWithStatus(entities.StatusAnalyzing)
+		current := newProposalWithStatus(entities.StatusRejected)
+		current.ID = stale.ID
+		reads := []*entities.Proposal{stale, current}
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				proposal := reads[0]
+				reads = reads[1:]
+				return proposal, nil
+			},
+			updateFn: func(ctx context.Context, p *entities.Proposal) error {
+				return domainErrors.ErrProposalVersionConflict
+			},
+		}
+		notifier := &mockNotifier{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, notifier, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventRiskAnalysisCompleted, stale.ID, true))
+
+		// The second attempt finds the proposal rejected and acknowledges the event.
+		assertNoError(t, err)
+		if len(reads) != 0 {
+			t.Errorf("expected the proposal to be read again, %d reads left", len(reads))
+		}
+		if len(notifier.notified) != 0 {
+			t.Errorf("expected no notification, got %v", notifier.notified)
+		}
+	})
+
+	t.Run("should acknowledge the losing event of two concurrent terminal events", func(t *testing.T) {
+		stored := newProposalWithStatus(entities.StatusAnalyzing)
+		stored.Version = 1
+		var mu sync.Mutex
+		var firstReads sync.WaitGroup
+		firstReads.Add(2)
+		reads := 0
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				mu.Lock()
+				reads++
+				first := reads <= 2
+				proposal := *stored
+				mu.Unlock()
+				// Both handlers read the same version before either writes.
+				if first {
+					firstReads.Done()
+					firstReads.Wait()
+				}
+				return &proposal, nil
+			},
+			updateFn: func(ctx context.Context, p *entities.Proposal) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if p.Version != stored.Version {
+					return domainErrors.ErrProposalVersionConflict
+				}
+				p.Version++
+				proposal := *p
+				stored = &proposal
+				return nil
+			},
+		}
+		history := &mockStatusHistoryRepository{}
+		notifier := &mockNotifier{}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, history, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, notifier, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		completed := newStatusChangedEvent(events.EventRiskAnalysisCompleted, stored.ID, true)
+		completed.CreditLimit = money.MustParse("3000")
+		rejected := newStatusChangedEvent(events.EventFraudRejected, stored.ID, false)
+		rejected.MessageID = "msg-2"
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i, event := range []*events.ProposalStatusChangedEvent{completed, rejected} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = handler.Handle(context.Background(), event)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			assertNoError(t, err)
+		}
+		if stored.Status != entities.StatusOfferPending && stored.Status != entities.StatusRejected {
+			t.Errorf("expected the winning event applied, got %q", stored.Status)
+		}
+		if stored.Version != 2 {
+			t.Errorf("expected a single update, got version %d", stored.Version)
+		}
+		if len(history.saved) != 1 || len(notifier.notified) != 1 {
+			t.Errorf("expected only the winner recorded and notified, got %d history entries and %d notifications",
+				len(history.saved), len(notifier.notified))
+		}
+		if reads != 3 {
+			t.Errorf("expected the loser to read the proposal again, got %d reads", reads)
+		}
+	})
+
+	t.Run("should give up after repeated version conflicts", func(t *testing.T) {
+		reads := 0
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				reads++
+				return newProposalWithStatus(entities.StatusAnalyzing), nil
+			},
+			updateFn: func(ctx context.Context, p *entities.Proposal) error {
+				return domainErrors.ErrProposalVersionConflict
+			},
+		}
+		handler := NewProposalStatusChangedEventHandler(repo, NewProposalTransitioner(repo, &mockStatusHistoryRepository{}, &mockDomainEventPublisher{}, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, &mockNotifier{}, &mockLogger{}), &mockLogger{}, OfferConfig{})
+
+		err := handler.Handle(context.Background(), newStatusChangedEvent(events.EventFraudRejected, uuid.New(), false))
+
+		if !errors.Is(err, domainErrors.ErrProposalVersionConflict) {
+			t.Errorf("expected %v, got %v", domainErrors.ErrProposalVersionConflict, err)
+		}
+		if reads != maxConflictAttempts {
+			t.Errorf("expected %d attempts, got %d", maxConflictAttempts, reads)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+// SnapshotEventType marks the first event of a stream, carrying the status
+// the proposal had when the client connected.
+const SnapshotEventType = "Snapshot"
+
+type StreamProposalEventsUseCase struct {
+	repository ports.ProposalRepository
+	subscriber ports.ProposalEventSubscriber
+}
+
+func NewStreamProposalEventsUseCase(
+	repo ports.ProposalRepository,
+	subscriber ports.ProposalEventSubscriber,
+) *StreamProposalEventsUseCase {
+	return &StreamProposalEventsUseCase{
+		repository: repo,
+		subscriber: subscriber,
+	}
+}
+
+// Execute streams the current status followed by every live event of the
+// proposal. The channel is closed once the proposal is finalized or ctx is
+// done.
+func (uc *StreamProposalEventsUseCase) Execute(ctx context.Context, id uuid.UUID) (<-chan *dto.ProposalEventResponse, error) {
+	// Subscribe before loading the proposal so no event is lost in between.
+	live, cancel, err := uc.subscriber.Subscribe(ctx, id)
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to subscribe to proposal events", err)
+	}
+
+	proposal, err := uc.repository.FindByID(ctx, id)
+	if err != nil {
+		cancel()
+		if errors.Is(err, domainErrors.ErrProposalNotFound) {
+			return nil, appErrors.NewNotFoundError("proposal")
+		}
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+
+	out := make(chan *dto.ProposalEventResponse, 1)
+	out <- &dto.ProposalEventResponse{
+		ProposalID: proposal.ID,
+		EventType:  SnapshotEventType,
+		Status:     string(proposal.Status),
+		Final:      proposal.IsFinalized(),
+		OccurredAt: time.Now(),
+	}
+
+	go func() {
+		defer close(out)
+		defer cancel()
+		if proposal.IsFinalized() {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-live:
+				if !ok {
+					return
+				}
+				select {
+				case out <- eventToResponse(event):
+				case <-ctx.Done():
+					return
+				}
+				if event.Final {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func eventToResponse(event *ports.ProposalEvent) *dto.ProposalEventResponse {
+	return &dto.ProposalEventResponse{
+		ProposalID: event.ProposalID,
+		EventType:  event.EventType,
+		Status:     event.Status,
+		Final:      event.Final,
+		OccurredAt: event.OccurredAt,
+	}
+}
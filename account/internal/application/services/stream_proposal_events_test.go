@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func collectEvents(t *testing.T, stream <-chan *dto.ProposalEventResponse) []*dto.ProposalEventResponse {
+	t.Helper()
+	var received []*dto.ProposalEventResponse
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event, ok := <-stream:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		case <-timeout:
+			t.Fatal("stream was not closed")
+		}
+	}
+}
+
+func TestStreamProposalEventsUseCase_Execute(t *testing.T) {
+	t.Run("should emit snapshot and live events until proposal is finalized", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		broker := &mockProposalEventBroker{events: make(chan *ports.ProposalEvent, 3)}
+		broker.events <- &ports.ProposalEvent{ProposalID: proposal.ID, EventType: "DocumentsApproved", Status: "analyzing"}
+		broker.events <- &ports.ProposalEvent{ProposalID: proposal.ID, EventType: "ProposalOfferAccepted", Status: "accepted", Final: true}
+		broker.events <- &ports.ProposalEvent{ProposalID: proposal.ID, EventType: "Late", Status: "accepted", Final: true}
+
+		uc := NewStreamProposalEventsUseCase(repo, broker)
+		stream, err := uc.Execute(context.Background(), proposal.ID)
+
+		assertNoError(t, err)
+		received := collectEvents(t, stream)
+		if len(received) != 3 {
+			t.Fatalf("expected 3 events, got %d", len(received))
+		}
+		if received[0].EventType != SnapshotEventType || received[0].Status != "pending" {
+			t.Errorf("expected pending snapshot first, got %+v", received[0])
+		}
+		if !received[2].Final {
+			t.Error("expected last event to be final")
+		}
+		if !broker.cancelled {
+			t.Error("expected subscription to be cancelled")
+		}
+	})
+
+	t.Run("should close right after snapshot when proposal is already finalized", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusRejected)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+
+		uc := NewStreamProposalEventsUseCase(repo, &mockProposalEventBroker{})
+		stream, err := uc.Execute(context.Background(), proposal.ID)
+
+		assertNoError(t, err)
+		received := collectEvents(t, stream)
+		if len(received) != 1 || !received[0].Final {
+			t.Errorf("expected a single final snapshot, got %+v", received)
+		}
+	})
+
+	t.Run("should stop streaming when context is cancelled", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		broker := &mockProposalEventBroker{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		uc := NewStreamProposalEventsUseCase(repo, broker)
+		stream, err := uc.Execute(ctx, proposal.ID)
+		assertNoError(t, err)
+		cancel()
+
+		received := collectEvents(t, stream)
+		if len(received) != 1 {
+			t.Errorf("expected only the snapshot, got %d events", len(received))
+		}
+	})
+
+	t.Run("should end the stream when the subscriber fell behind", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return proposal, nil
+			},
+		}
+		broker := &mockProposalEventBroker{events: make(chan *ports.ProposalEvent, 1)}
+		broker.events <- &ports.ProposalEvent{ProposalID: proposal.ID, EventType: "DocumentsApproved", Status: "analyzing"}
+		close(broker.events)
+
+		uc := NewStreamProposalEventsUseCase(repo, broker)
+		stream, err := uc.Execute(context.Background(), proposal.ID)
+
+		assertNoError(t, err)
+		received := collectEvents(t, stream)
+		if len(received) != 2 || received[1].Final {
+			t.Errorf("expected the snapshot and the delivered event before the stream ends, got %+v", received)
+		}
+	})
+
+	t.Run("should return NOT_FOUND when proposal doesn't exist", func(t *testing.T) {
+		repo := &mockRepository{
+			findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+				return nil, domainErrors.ErrProposalNotFound
+			},
+		}
+		broker := &mockProposalEventBroker{}
+
+		uc := NewStreamProposalEventsUseCase(repo, broker)
+		_, err := uc.Execute(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "NOT_FOUND", http.StatusNotFound)
+		if !broker.cancelled {
+			t.Error("expected subscription to be cancelled")
+		}
+	})
+}
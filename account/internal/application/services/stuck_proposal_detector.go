@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+// Default SLAs of StuckProposalDetector. Pending proposals wait on the
+// customer's documents as well as on risk-analysis, so they get longer.
+const (
+	DefaultPendingSLA     = 24 * time.Hour
+	DefaultAnalyzingSLA   = 30 * time.Minute
+	DefaultMaxRepublishes = 3
+)
+
+// stuckProposalLock is the JobLock name shared by every replica.
+const stuckProposalLock = "account:stuck-proposal-detector"
+
+// StuckProposalDetectorConfig sets how long a proposal may stay in pending
+// or analyzing, counted from its last change, and how many times the
+// analysis is requested again before the proposal expires. AwaitDocuments
+// must match CreateProposalConfig.AwaitDocuments.
+type StuckProposalDetectorConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	PendingSLA     time.Duration
+	AnalyzingSLA   time.Duration
+	MaxRepublishes int
+	AwaitDocuments bool
+}
+
+// StuckProposalDetector handles proposals that risk-analysis never answered,
+// because it was down or a message was lost. ProposalCreated is published
+// again up to MaxRepublishes times, then the proposal expires. With
+// AwaitDocuments, pending proposals still missing documents were never sent
+// to risk-analysis, so they expire without a new request. Only the replica
+// holding the lock runs a sweep.
+type StuckProposalDetector struct {
+	repository  ports.ProposalRepository
+	documents   ports.DocumentRepository
+	publisher   domainEventPublisher
+	transitions *ProposalTransitioner
+	txManager   ports.TransactionManager
+	lock        ports.JobLock
+	logger      ports.Logger
+	cfg         StuckProposalDetectorConfig
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewStuckProposalDetector(
+	repo ports.ProposalRepository,
+	documents ports.DocumentRepository,
+	publisher domainEventPublisher,
+	transitions *ProposalTransitioner,
+	txManager ports.TransactionManager,
+	lock ports.JobLock,
+	logger ports.Logger,
+	cfg StuckProposalDetectorConfig,
+) *StuckProposalDetector {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = time.Minute
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.PendingSLA == 0 {
+		cfg.PendingSLA = DefaultPendingSLA
+	}
+	if cfg.AnalyzingSLA == 0 {
+		cfg.AnalyzingSLA = DefaultAnalyzingSLA
+	}
+	if cfg.MaxRepublishes == 0 {
+		cfg.MaxRepublishes = DefaultMaxRepublishes
+	}
+
+	return &StuckProposalDetector{
+		repository:  repo,
+		documents:   documents,
+		publisher:   publisher,
+		transitions: transitions,
+		txManager:   txManager,
+		lock:        lock,
+		logger:      logger,
+		cfg:         cfg,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (d *StuckProposalDetector) Start(ctx context.Context) error {
+	d.wg.Add(1)
+	go d.run(ctx)
+	return nil
+}
+
+func (d *StuckProposalDetector) Stop() error {
+	close(d.stopCh)
+	d.wg.Wait()
+	return nil
+}
+
+func (d *StuckProposalDetector) run(ctx context.Context) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			if err := d.DetectStuck(ctx); err != nil {
+				d.logger.Error(ctx, "failed to detect stuck proposals", "error", err)
+			}
+		}
+	}
+}
+
+// DetectStuck handles one batch of proposals past their SLA per status. It
+// does nothing while another replica holds the lock. A failing proposal is
+// logged and retried on the next tick.
+func (d *StuckProposalDetector) DetectStuck(ctx context.Context) error {
+	unlock, acquired, err := d.lock.TryLock(ctx, stuckProposalLock)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer unlock()
+
+	now := time.Now()
+	slas := []struct {
+		status entities.ProposalStatus
+		sla    time.Duration
+	}{
+		{status: entities.StatusPending, sla: d.cfg.PendingSLA},
+		{status: entities.StatusAnalyzing, sla: d.cfg.AnalyzingSLA},
+	}
+	for _, s := range slas {
+		deadline := now.Add(-s.sla)
+		proposals, err := d.repository.List(ctx, ports.ProposalFilter{
+			Status:        s.status,
+			UpdatedBefore: &deadline,
+			SortField:     ports.SortByUpdatedAt,
+			Limit:         d.cfg.BatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, proposal := range proposals {
+			if err := d.handle(ctx, proposal); err != nil {
+				d.logger.Warn(ctx, "failed to handle stuck proposal", "proposal_id", proposal.ID, "status", proposal.Status, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *StuckProposalDetector) handle(ctx context.Context, proposal *entities.Proposal) error {
+	documents, err := d.documents.FindByProposalID(ctx, proposal.ID)
+	if err != nil {
+		return err
+	}
+
+	awaitingDocuments := d.cfg.AwaitDocuments && !entities.HasRequiredDocuments(documents)
+	if awaitingDocuments || proposal.AnalysisRetries >= d.cfg.MaxRepublishes {
+		if err := d.transitions.Transition(ctx, proposal, events.EventProposalExpired, "", proposal.Expire); err != nil {
+			return err
+		}
+		d.logger.Info(ctx, "stuck proposal expired", "proposal_id", proposal.ID, "retries", proposal.AnalysisRetries)
+		return nil
+	}
+
+	if err := proposal.RetryAnalysis(); err != nil {
+		return err
+	}
+	err = d.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := d.repository.Update(ctx, proposal); err != nil {
+			return err
+		}
+		metadata, err := documentMetadata(ctx, d.documents, proposal, documents)
+		if err != nil {
+			return err
+		}
+		return d.publisher.Publish(ctx, newProposalCreatedEvent(proposal, metadata))
+	})
+	if err != nil {
+		return err
+	}
+	d.logger.Info(ctx, "analysis requested again for stuck proposal", "proposal_id", proposal.ID, "retry", proposal.AnalysisRetries)
+	return nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func TestStuckProposalDetector_DetectStuck(t *testing.T) {
+	type fixture struct {
+		detector  *StuckProposalDetector
+		repo      *mockRepository
+		documents *mockDocumentRepository
+		publisher *mockDomainEventPublisher
+		notifier  *mockNotifier
+		lock      *mockJobLock
+		filters   []ports.ProposalFilter
+	}
+
+	requiredDocuments := func() []*entities.Document {
+		return []*entities.Document{
+			{ID: uuid.New(), Type: entities.DocumentIdentity, ContentType: "image/png", SHA256: "a"},
+			{ID: uuid.New(), Type: entities.DocumentProofOfIncome, ContentType: "application/pdf", SHA256: "b"},
+		}
+	}
+
+	// setup returns stuck only for its own status, as the repository would.
+	setup := func(stuck *entities.Proposal, documents []*entities.Document, awaitDocuments bool) *fixture {
+		f := &fixture{
+			documents: &mockDocumentRepository{existing: documents},
+			publisher: &mockDomainEventPublisher{},
+			notifier:  &mockNotifier{},
+			lock:      &mockJobLock{},
+		}
+		f.repo = &mockRepository{
+			listFn: func(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+				f.filters = append(f.filters, filter)
+				if stuck != nil && filter.Status == stuck.Status {
+					return []*entities.Proposal{stuck}, nil
+				}
+				return nil, nil
+			},
+		}
+		transitions := NewProposalTransitioner(f.repo, &mockStatusHistoryRepository{}, f.publisher, &mockWebhookEnqueuer{}, &mockProposalEventBroker{}, &mockTxManager{}, f.notifier, &mockLogger{})
+		f.detector = NewStuckProposalDetector(f.repo, f.documents, f.publisher, transitions, &mockTxManager{}, f.lock, &mockLogger{}, StuckProposalDetectorConfig{
+			BatchSize:      5,
+			PendingSLA:     time.Hour,
+			AnalyzingSLA:   10 * time.Minute,
+			MaxRepublishes: 2,
+			AwaitDocuments: awaitDocuments,
+		})
+		return f
+	}
+
+	t.Run("should look for proposals past the SLA of each status", func(t *testing.T) {
+		f := setup(nil, nil, false)
+		before := time.Now()
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if len(f.filters) != 2 {
+			t.Fatalf("expected one query per status, got %+v", f.filters)
+		}
+		slas := map[entities.ProposalStatus]time.Duration{entities.StatusPending: time.Hour, entities.StatusAnalyzing: 10 * time.Minute}
+		for _, filter := range f.filters {
+			sla, ok := slas[filter.Status]
+			if !ok || filter.UpdatedBefore == nil || filter.Limit != 5 {
+				t.Fatalf("unexpected filter %+v", filter)
+			}
+			if deadline := before.Add(-sla); filter.UpdatedBefore.Before(deadline) || filter.UpdatedBefore.After(time.Now().Add(-sla)) {
+				t.Errorf("expected %s deadline around %v, got %v", filter.Status, deadline, *filter.UpdatedBefore)
+			}
+		}
+		if len(f.lock.locked) != 1 || f.lock.unlocked != 1 {
+			t.Errorf("expected the lock to be taken and released once, got %d/%d", len(f.lock.locked), f.lock.unlocked)
+		}
+	})
+
+	t.Run("should request the analysis again while under the retry limit", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		proposal.AnalysisRetries = 1
+		f := setup(proposal, requiredDocuments(), false)
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if proposal.Status != entities.StatusAnalyzing || proposal.AnalysisRetries != 2 {
+			t.Errorf("expected analyzing with 2 retries, got %q with %d", proposal.Status, proposal.AnalysisRetries)
+		}
+		if len(f.repo.updated) != 1 {
+			t.Errorf("expected the retry count persisted, got %d updates", len(f.repo.updated))
+		}
+		if len(f.publisher.published) != 1 {
+			t.Fatalf("expected 1 published event, got %d", len(f.publisher.published))
+		}
+		event, ok := f.publisher.published[0].(*events.ProposalCreatedEvent)
+		if !ok || event.ProposalID != proposal.ID || len(event.Payload.Documents) != 2 {
+			t.Errorf("expected ProposalCreated with the documents, got %+v", f.publisher.published[0])
+		}
+		if len(f.notifier.notified) != 0 {
+			t.Errorf("expected no notification, got %v", f.notifier.notified)
+		}
+	})
+
+	t.Run("should expire the proposal once the retries are exhausted", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		proposal.AnalysisRetries = 2
+		f := setup(proposal, requiredDocuments(), false)
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if proposal.Status != entities.StatusExpired {
+			t.Errorf("expected status expired, got %q", proposal.Status)
+		}
+		for _, event := range f.publisher.published {
+			if event.EventName() == events.EventProposalCreated {
+				t.Errorf("expected no new analysis request")
+			}
+		}
+		if len(f.notifier.notified) != 1 || f.notifier.notified[0] != entities.StatusExpired {
+			t.Errorf("expected customer notified of the expiry, got %v", f.notifier.notified)
+		}
+	})
+
+	t.Run("should expire pending proposals missing documents without a new request when awaiting them", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		f := setup(proposal, requiredDocuments()[:1], true)
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if proposal.Status != entities.StatusExpired || proposal.AnalysisRetries != 0 {
+			t.Errorf("expected expired without retries, got %q with %d", proposal.Status, proposal.AnalysisRetries)
+		}
+	})
+
+	t.Run("should request the analysis again for proposals without documents when not awaiting them", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		f := setup(proposal, nil, false)
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if proposal.Status != entities.StatusPending || proposal.AnalysisRetries != 1 {
+			t.Errorf("expected pending with 1 retry, got %q with %d", proposal.Status, proposal.AnalysisRetries)
+		}
+		if len(f.publisher.published) != 1 || f.publisher.published[0].EventName() != events.EventProposalCreated {
+			t.Errorf("expected ProposalCreated published again, got %+v", f.publisher.published)
+		}
+	})
+
+	t.Run("should skip the sweep while another replica holds the lock", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		f := setup(proposal, requiredDocuments(), false)
+		f.lock.heldElsewhere = true
+
+		assertNoError(t, f.detector.DetectStuck(context.Background()))
+
+		if len(f.filters) != 0 || len(f.repo.updated) != 0 || proposal.Status != entities.StatusAnalyzing {
+			t.Errorf("expected nothing done, got %d queries and %d updates", len(f.filters), len(f.repo.updated))
+		}
+	})
+}
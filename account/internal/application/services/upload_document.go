@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+// UploadDocumentConfig limits the uploaded files. MaxSize defaults to
+// entities.DefaultMaxDocumentSize.
+type UploadDocumentConfig struct {
+	MaxSize int64
+}
+
+// UploadDocumentUseCase stores a document of a pending proposal. The upload
+// that completes entities.RequiredDocumentTypes sends the proposal to
+// risk-analysis with the metadata of every document. Unless the analysis
+// awaits the documents, it was also requested when the proposal was created,
+// without documents; this second request is the one that checks them.
+type UploadDocumentUseCase struct {
+	repository ports.ProposalRepository
+	documents  ports.DocumentRepository
+	store      ports.DocumentStore
+	publisher  domainEventPublisher
+	txManager  ports.TransactionManager
+	logger     ports.Logger
+	policy     entities.DocumentPolicy
+}
+
+// documentErrorFields reports document validation errors as fields of the
+// multipart form.
+var documentErrorFields = []struct {
+	err     error
+	pointer string
+	code    string
+}{
+	{err: domainErrors.ErrDocumentTypeInvalid, pointer: "/type", code: "DOCUMENT_TYPE_INVALID"},
+	{err: domainErrors.ErrDocumentEmpty, pointer: "/file", code: "DOCUMENT_EMPTY"},
+	{err: domainErrors.ErrDocumentTooLarge, pointer: "/file", code: "DOCUMENT_TOO_LARGE"},
+	{err: domainErrors.ErrDocumentContentTypeNotAllowed, pointer: "/file", code: "DOCUMENT_CONTENT_TYPE_NOT_ALLOWED"},
+}
+
+func NewUploadDocumentUseCase(
+	repo ports.ProposalRepository,
+	documents ports.DocumentRepository,
+	store ports.DocumentStore,
+	publisher domainEventPublisher,
+	txManager ports.TransactionManager,
+	logger ports.Logger,
+	cfg UploadDocumentConfig,
+) *UploadDocumentUseCase {
+	return &UploadDocumentUseCase{
+		repository: repo,
+		documents:  documents,
+		store:      store,
+		publisher:  publisher,
+		txManager:  txManager,
+		logger:     logger,
+		policy:     entities.DocumentPolicy{MaxSize: cfg.MaxSize},
+	}
+}
+
+func (uc *UploadDocumentUseCase) Execute(
+	ctx context.Context,
+	proposalID uuid.UUID,
+	req *dto.UploadDocumentRequest,
+) (*dto.DocumentResponse, error) {
+	proposal, err := uc.repository.FindByID(ctx, proposalID)
+	if err != nil && errors.Is(err, domainErrors.ErrProposalNotFound) {
+		return nil, appErrors.NewNotFoundError("proposal")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to fetch proposal", err)
+	}
+
+	document, err := entities.NewDocument(proposal.ID, entities.DocumentType(req.Type), req.FileName, req.Content, uc.policy)
+	if err != nil {
+		return nil, newDocumentValidationError(err)
+	}
+	if err := proposal.AttachDocument(); err != nil {
+		return nil, appErrors.NewConflictError("PROPOSAL_NOT_PENDING", err)
+	}
+
+	if err := uc.store.Put(ctx, document.StorageKey, document.ContentType, req.Content); err != nil {
+		uc.logger.Error(ctx, "failed to store document", "proposal_id", proposal.ID, "error", err)
+		return nil, appErrors.NewInternalError("failed to store document", err)
+	}
+
+	var analysisRequested bool
+	// Updating the proposal first locks its row and checks its version, so
+	// concurrent uploads see each other's documents and only the one
+	// completing the set publishes. The upload that lost the race reads the
+	// proposal again and retries.
+	attempts := 0
+	err = retryOnConflict(ctx, uc.logger, func() error {
+		if attempts++; attempts > 1 {
+			if proposal, err = uc.repository.FindByID(ctx, proposalID); err != nil {
+				return err
+			}
+			if err := proposal.AttachDocument(); err != nil {
+				return err
+			}
+		}
+		analysisRequested, err = uc.save(ctx, proposal, document)
+		return err
+	})
+	if err != nil {
+		if err := uc.store.Delete(ctx, document.StorageKey); err != nil {
+			uc.logger.Error(ctx, "failed to delete orphan document", "storage_key", document.StorageKey, "error", err)
+		}
+	}
+	switch {
+	case errors.Is(err, domainErrors.ErrDocumentsAlreadySubmitted):
+		return nil, appErrors.NewConflictError("DOCUMENTS_ALREADY_SUBMITTED", err)
+	case errors.Is(err, domainErrors.ErrOnlyPendingCanReceiveDocuments):
+		return nil, appErrors.NewConflictError("PROPOSAL_NOT_PENDING", err)
+	case errors.Is(err, domainErrors.ErrProposalVersionConflict):
+		return nil, appErrors.NewConflictError("PROPOSAL_VERSION_CONFLICT", err)
+	case err != nil:
+		uc.logger.Error(ctx, "failed to save document", "proposal_id", proposal.ID, "error", err)
+		return nil, appErrors.NewInternalError("failed to save document", err)
+	}
+
+	uc.logger.Info(ctx, "document uploaded", "proposal_id", proposal.ID, "type", document.Type, "analysis_requested", analysisRequested)
+	return &dto.DocumentResponse{
+		ID:                document.ID,
+		ProposalID:        document.ProposalID,
+		Type:              string(document.Type),
+		FileName:          document.FileName,
+		ContentType:       document.ContentType,
+		Size:              document.Size,
+		SHA256:            document.SHA256,
+		UploadedAt:        document.UploadedAt,
+		AnalysisRequested: analysisRequested,
+	}, nil
+}
+
+func newDocumentValidationError(err error) *appErrors.ApplicationError {
+	for _, field := range documentErrorFields {
+		if errors.Is(err, field.err) {
+			return appErrors.NewValidationError([]appErrors.FieldError{
+				{Pointer: field.pointer, Code: field.code, Message: err.Error()},
+			})
+		}
+	}
+	return appErrors.NewInvalidInputError(err)
+}
+
+// save stores the document metadata and, when the documents are now
+// complete, requests the analysis, in a single transaction.
+func (uc *UploadDocumentUseCase) save(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	document *entities.Document,
+) (analysisRequested bool, err error) {
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.Update(ctx, proposal); err != nil {
+			return err
+		}
+		documents, err := uc.documents.FindByProposalID(ctx, proposal.ID)
+		if err != nil {
+			return err
+		}
+		if entities.HasRequiredDocuments(documents) {
+			return domainErrors.ErrDocumentsAlreadySubmitted
+		}
+		if err := uc.documents.Save(ctx, document); err != nil {
+			return err
+		}
+
+		documents = append(documents, document)
+		if !entities.HasRequiredDocuments(documents) {
+			return nil
+		}
+		metadata, err := documentMetadata(ctx, uc.documents, proposal, documents)
+		if err != nil {
+			return err
+		}
+		analysisRequested = true
+		// The event is relayed to the queue by OutboxRelay once the transaction commits.
+		return uc.publisher.Publish(ctx, newProposalCreatedEvent(proposal, metadata))
+	})
+	return analysisRequested && err == nil, err
+}
+
+// documentMetadata describes the documents sent to risk-analysis. Each
+// document is flagged when the same file was used by another CPF, which
+// risk-analysis cannot check on its own.
+func documentMetadata(
+	ctx context.Context,
+	repo ports.DocumentRepository,
+	proposal *entities.Proposal,
+	documents []*entities.Document,
+) ([]events.DocumentMetadata, error) {
+	metadata := make([]events.DocumentMetadata, 0, len(documents))
+	for _, document := range documents {
+		usedByOtherCPF, err := repo.ExistsForOtherCPF(ctx, document.SHA256, proposal.CPF)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, events.DocumentMetadata{
+			ID:             document.ID,
+			Type:           string(document.Type),
+			ContentType:    document.ContentType,
+			Size:           document.Size,
+			SHA256:         document.SHA256,
+			Width:          document.Width,
+			Height:         document.Height,
+			Pages:          document.Pages,
+			UsedByOtherCPF: usedByOtherCPF,
+		})
+	}
+	return metadata, nil
+}
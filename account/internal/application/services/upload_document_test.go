@@ -0,0 +1,231 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj\n<< /Type /Page >>\nendobj\n%%EOF")
+
+func TestUploadDocumentUseCase(t *testing.T) {
+	type fixture struct {
+		useCase   *UploadDocumentUseCase
+		repo      *mockRepository
+		documents *mockDocumentRepository
+		store     *mockDocumentStore
+		outbox    *mockOutboxRepository
+	}
+	setup := func(cfg UploadDocumentConfig, proposal *entities.Proposal, existing ...*entities.Document) *fixture {
+		f := &fixture{
+			repo: &mockRepository{
+				findByIDFn: func(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
+					if proposal == nil {
+						return nil, events.ErrProposalNotFound
+					}
+					return proposal, nil
+				},
+			},
+			documents: &mockDocumentRepository{existing: existing},
+			store:     &mockDocumentStore{},
+			outbox:    &mockOutboxRepository{},
+		}
+		f.useCase = NewUploadDocumentUseCase(f.repo, f.documents, f.store, NewOutboxEventPublisher(f.outbox), &mockTxManager{}, &mockLogger{}, cfg)
+		return f
+	}
+	pendingProposal := func() *entities.Proposal {
+		proposal := newProposalWithStatus(entities.StatusPending)
+		proposal.Salary = money.MustParse("5000")
+		proposal.BirthDate = time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC)
+		return proposal
+	}
+	upload := func(docType entities.DocumentType) *dto.UploadDocumentRequest {
+		return &dto.UploadDocumentRequest{Type: string(docType), FileName: "file.pdf", Content: pdfContent}
+	}
+
+	t.Run("should store the document without requesting analysis", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal)
+
+		response, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentIdentity))
+
+		assertNoError(t, err)
+		if response.AnalysisRequested || len(f.outbox.saved) != 0 {
+			t.Error("expected no analysis request with a single document")
+		}
+		if response.ContentType != "application/pdf" || len(response.SHA256) != 64 {
+			t.Errorf("expected sniffed type and hash, got %q %q", response.ContentType, response.SHA256)
+		}
+		if len(f.documents.saved) != 1 || !bytes.Equal(f.store.stored[f.documents.saved[0].StorageKey], pdfContent) {
+			t.Error("expected document saved and stored under its key")
+		}
+	})
+
+	t.Run("should request analysis with document metadata once required documents are uploaded", func(t *testing.T) {
+		proposal := pendingProposal()
+		identity := &entities.Document{ID: uuid.New(), Type: entities.DocumentIdentity, ContentType: "image/png", Size: 10, SHA256: "abc", Width: 800, Height: 600}
+		f := setup(UploadDocumentConfig{}, proposal, identity)
+		f.documents.usedByOtherCPF = map[string]bool{"abc": true}
+
+		response, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentProofOfIncome))
+
+		assertNoError(t, err)
+		if !response.AnalysisRequested {
+			t.Error("expected analysis requested")
+		}
+		if len(f.outbox.saved) != 1 {
+			t.Fatalf("expected 1 outbox message, got %d", len(f.outbox.saved))
+		}
+		message := f.outbox.saved[0]
+		if message.EventType != events.EventProposalCreated || message.AggregateID != proposal.ID || message.Destination != ports.QueueProposals {
+			t.Errorf("unexpected outbox message %+v", message)
+		}
+
+		var event events.ProposalCreatedEvent
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			t.Fatalf("failed to decode outbox payload: %v", err)
+		}
+		if event.Payload.BirthDate != "1990-01-15" {
+			t.Errorf("expected ISO birth date in payload, got %q", event.Payload.BirthDate)
+		}
+		if event.Payload.Salary != money.MustParse("5000") || event.Payload.SalaryCents != 500000 {
+			t.Errorf("expected salary 5000.00 and 500000 centavos in payload, got %s and %d", event.Payload.Salary, event.Payload.SalaryCents)
+		}
+		if !bytes.Contains(message.Payload, []byte(`"salary":5000.00,"salary_cents":500000`)) {
+			t.Errorf("expected salary as a JSON number for existing consumers, got %s", message.Payload)
+		}
+		if len(event.Payload.Documents) != 2 {
+			t.Fatalf("expected 2 documents in payload, got %+v", event.Payload.Documents)
+		}
+		if got := event.Payload.Documents[0]; got.ID != identity.ID || got.Type != "identity" || got.SHA256 != "abc" || got.Width != 800 || !got.UsedByOtherCPF {
+			t.Errorf("unexpected document metadata %+v", got)
+		}
+		if got := event.Payload.Documents[1]; got.Pages != 1 || got.UsedByOtherCPF {
+			t.Errorf("expected a one page PDF not used by another CPF, got %+v", got)
+		}
+	})
+
+	t.Run("should request analysis again with the documents when it was requested on creation", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal, &entities.Document{ID: uuid.New(), Type: entities.DocumentIdentity})
+
+		response, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentProofOfIncome))
+
+		assertNoError(t, err)
+		if !response.AnalysisRequested || len(f.outbox.saved) != 1 {
+			t.Fatal("expected the analysis requested again")
+		}
+		var event events.ProposalCreatedEvent
+		if err := json.Unmarshal(f.outbox.saved[0].Payload, &event); err != nil {
+			t.Fatalf("failed to decode outbox payload: %v", err)
+		}
+		if len(event.Payload.Documents) != 2 {
+			t.Errorf("expected both documents in payload, got %+v", event.Payload.Documents)
+		}
+	})
+
+	t.Run("should return conflict once documents were submitted", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal, &entities.Document{Type: entities.DocumentIdentity}, &entities.Document{Type: entities.DocumentProofOfIncome})
+
+		_, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentIdentity))
+
+		assertApplicationError(t, err, "DOCUMENTS_ALREADY_SUBMITTED", 409)
+		if len(f.store.stored) != 0 || len(f.store.deleted) != 1 {
+			t.Error("expected the stored file to be deleted")
+		}
+	})
+
+	t.Run("should retry with the current proposal when a concurrent upload updated it first", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal, &entities.Document{ID: uuid.New(), Type: entities.DocumentIdentity, ContentType: "image/png", SHA256: "abc"})
+		conflicts := 1
+		f.repo.updateFn = func(ctx context.Context, p *entities.Proposal) error {
+			if conflicts > 0 {
+				conflicts--
+				return events.ErrProposalVersionConflict
+			}
+			f.repo.updated = append(f.repo.updated, p)
+			return nil
+		}
+
+		response, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentProofOfIncome))
+
+		assertNoError(t, err)
+		if !response.AnalysisRequested || len(f.repo.updated) != 1 || len(f.documents.saved) != 1 {
+			t.Errorf("expected the retry to save the document and request analysis, got %+v", response)
+		}
+		if len(f.store.deleted) != 0 {
+			t.Errorf("expected the stored file to be kept, got %v deleted", f.store.deleted)
+		}
+	})
+
+	t.Run("should return conflict when the proposal keeps changing", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal)
+		f.repo.updateFn = func(ctx context.Context, p *entities.Proposal) error {
+			return events.ErrProposalVersionConflict
+		}
+
+		_, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentIdentity))
+
+		assertApplicationError(t, err, "PROPOSAL_VERSION_CONFLICT", 409)
+		if len(f.store.stored) != 0 || len(f.store.deleted) != 1 {
+			t.Error("expected the stored file to be deleted")
+		}
+	})
+
+	t.Run("should return conflict when proposal is not pending", func(t *testing.T) {
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		f := setup(UploadDocumentConfig{}, proposal)
+
+		_, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentIdentity))
+
+		assertApplicationError(t, err, "PROPOSAL_NOT_PENDING", 409)
+		if len(f.store.stored) != 0 {
+			t.Error("expected nothing stored")
+		}
+	})
+
+	t.Run("should report invalid files as field errors", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal)
+
+		_, err := f.useCase.Execute(context.Background(), proposal.ID, &dto.UploadDocumentRequest{Type: "identity", Content: []byte("plain text")})
+
+		assertApplicationError(t, err, "INVALID_INPUT", 400)
+		assertFields(t, err, appErrors.FieldError{Pointer: "/file", Code: "DOCUMENT_CONTENT_TYPE_NOT_ALLOWED"})
+	})
+
+	t.Run("should return internal error when the store fails", func(t *testing.T) {
+		proposal := pendingProposal()
+		f := setup(UploadDocumentConfig{}, proposal)
+		f.store.putErr = errors.New("bucket unavailable")
+
+		_, err := f.useCase.Execute(context.Background(), proposal.ID, upload(entities.DocumentIdentity))
+
+		assertApplicationError(t, err, "INTERNAL_ERROR", 500)
+		if len(f.documents.saved) != 0 {
+			t.Error("expected no document saved")
+		}
+	})
+
+	t.Run("should return not found when proposal does not exist", func(t *testing.T) {
+		f := setup(UploadDocumentConfig{}, nil)
+
+		_, err := f.useCase.Execute(context.Background(), uuid.New(), upload(entities.DocumentIdentity))
+
+		assertApplicationError(t, err, "NOT_FOUND", 404)
+	})
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+const (
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+type WebhookDispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	MaxAttempts  int
+	// ClaimLease is how long a claimed delivery is hidden from other
+	// dispatchers. It must outlast sending a whole batch; a delivery whose
+	// result was not recorded in time is sent again.
+	ClaimLease time.Duration
+}
+
+// WebhookDispatcher sends pending webhook deliveries to partner endpoints,
+// retrying with exponential backoff until a delivery goes dead.
+type WebhookDispatcher struct {
+	subscriptions ports.WebhookSubscriptionRepository
+	deliveries    ports.WebhookDeliveryRepository
+	sender        ports.WebhookSender
+	logger        ports.Logger
+	cfg           WebhookDispatcherConfig
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+func NewWebhookDispatcher(
+	subscriptions ports.WebhookSubscriptionRepository,
+	deliveries ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	logger ports.Logger,
+	cfg WebhookDispatcherConfig,
+) *WebhookDispatcher {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 10
+	}
+	if cfg.BaseBackoff == 0 {
+		cfg.BaseBackoff = 5 * time.Second
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.ClaimLease == 0 {
+		cfg.ClaimLease = 5 * time.Minute
+	}
+
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		logger:        logger,
+		cfg:           cfg,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	d.wg.Add(1)
+	go d.run(ctx)
+	return nil
+}
+
+func (d *WebhookDispatcher) Stop() error {
+	close(d.stopCh)
+	d.wg.Wait()
+	return nil
+}
+
+func (d *WebhookDispatcher) run(ctx context.Context) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			if err := d.DispatchPending(ctx); err != nil {
+				d.logger.Error(ctx, "failed to dispatch webhooks", "error", err)
+			}
+		}
+	}
+}
+
+// DispatchPending claims one batch of due deliveries and sends them. No
+// transaction is held while partners are called. Failed deliveries are
+// rescheduled, or marked dead once they run out of attempts.
+func (d *WebhookDispatcher) DispatchPending(ctx context.Context) error {
+	deliveries, err := d.deliveries.ClaimDue(ctx, d.cfg.BatchSize, time.Now().Add(d.cfg.ClaimLease))
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			delivery.MarkFailed(err, time.Now().Add(exponentialBackoff(d.cfg.BaseBackoff, d.cfg.MaxBackoff, delivery.Attempts+1)), d.cfg.MaxAttempts)
+			d.logger.Warn(ctx, "failed to deliver webhook",
+				"delivery_id", delivery.ID, "attempts", delivery.Attempts, "error", err)
+			if delivery.Status == entities.WebhookDeliveryDead {
+				d.logger.Error(ctx, "webhook delivery is dead", "delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID)
+			}
+		} else {
+			delivery.MarkDelivered()
+		}
+
+		// The claim lease runs out on its own, so a delivery whose result
+		// cannot be recorded is retried instead of lost.
+		if err := d.deliveries.Update(ctx, delivery); err != nil {
+			d.logger.Error(ctx, "failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	subscription, err := d.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("load subscription: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return d.sender.Send(ctx, &ports.WebhookRequest{
+		URL: subscription.URL,
+		Headers: map[string]string{
+			WebhookIDHeader:        delivery.ID.String(),
+			WebhookEventHeader:     delivery.EventType,
+			WebhookTimestampHeader: timestamp,
+			WebhookSignatureHeader: signWebhook(subscription.Secret, timestamp, delivery.Payload),
+		},
+		Body: delivery.Payload,
+	})
+}
+
+// signWebhook signs "<timestamp>.<body>" so partners can reject replayed
+// requests by checking the timestamp.
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+func TestWebhookDispatcher_DispatchPending(t *testing.T) {
+	t.Run("should send signed payload and mark delivered", func(t *testing.T) {
+		subscription := newWebhookSubscription(t)
+		delivery := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{"id":"1"}`))
+		deliveries := &mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}}
+		sender := &mockWebhookSender{}
+
+		dispatcher := NewWebhookDispatcher(
+			&mockWebhookSubscriptionRepository{subscriptions: []*entities.WebhookSubscription{subscription}},
+			deliveries, sender, &mockLogger{}, WebhookDispatcherConfig{},
+		)
+		err := dispatcher.DispatchPending(context.Background())
+
+		assertNoError(t, err)
+		if len(sender.sent) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(sender.sent))
+		}
+		request := sender.sent[0]
+		if request.URL != subscription.URL {
+			t.Errorf("expected url %q, got %q", subscription.URL, request.URL)
+		}
+		timestamp := request.Headers[WebhookTimestampHeader]
+		if want := signWebhook(subscription.Secret, timestamp, delivery.Payload); request.Headers[WebhookSignatureHeader] != want {
+			t.Errorf("expected signature %q, got %q", want, request.Headers[WebhookSignatureHeader])
+		}
+		if delivery.Status != entities.WebhookDeliveryDelivered {
+			t.Errorf("expected status delivered, got %q", delivery.Status)
+		}
+		if len(deliveries.updated) != 1 {
+			t.Errorf("expected 1 update, got %d", len(deliveries.updated))
+		}
+	})
+
+	t.Run("should reschedule failed delivery with backoff", func(t *testing.T) {
+		subscription := newWebhookSubscription(t)
+		delivery := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{}`))
+		delivery.Attempts = 1
+		sender := &mockWebhookSender{
+			sendFn: func(ctx context.Context, request *ports.WebhookRequest) error {
+				return errors.New("connection refused")
+			},
+		}
+
+		dispatcher := NewWebhookDispatcher(
+			&mockWebhookSubscriptionRepository{subscriptions: []*entities.WebhookSubscription{subscription}},
+			&mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}},
+			sender, &mockLogger{},
+			WebhookDispatcherConfig{BaseBackoff: time.Second, MaxBackoff: time.Minute, MaxAttempts: 5},
+		)
+		before := time.Now()
+		err := dispatcher.DispatchPending(context.Background())
+
+		assertNoError(t, err)
+		if delivery.Status != entities.WebhookDeliveryPending {
+			t.Errorf("expected status pending, got %q", delivery.Status)
+		}
+		if delivery.Attempts != 2 {
+			t.Errorf("expected 2 attempts, got %d", delivery.Attempts)
+		}
+		if delay := delivery.NextAttemptAt.Sub(before); delay < 2*time.Second {
+			t.Errorf("expected backoff of at least 2s, got %v", delay)
+		}
+	})
+
+	t.Run("should mark delivery dead after max attempts", func(t *testing.T) {
+		subscription := newWebhookSubscription(t)
+		delivery := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{}`))
+		delivery.Attempts = 4
+		sender := &mockWebhookSender{
+			sendFn: func(ctx context.Context, request *ports.WebhookRequest) error {
+				return errors.New("status 500")
+			},
+		}
+
+		dispatcher := NewWebhookDispatcher(
+			&mockWebhookSubscriptionRepository{subscriptions: []*entities.WebhookSubscription{subscription}},
+			&mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}},
+			sender, &mockLogger{},
+			WebhookDispatcherConfig{MaxAttempts: 5},
+		)
+		err := dispatcher.DispatchPending(context.Background())
+
+		assertNoError(t, err)
+		if delivery.Status != entities.WebhookDeliveryDead {
+			t.Errorf("expected status dead, got %q", delivery.Status)
+		}
+		if delivery.LastError == "" {
+			t.Error("expected last error to be recorded")
+		}
+	})
+	t.Run("should claim deliveries with a lease before sending", func(t *testing.T) {
+		subscription := newWebhookSubscription(t)
+		delivery := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{}`))
+		deliveries := &mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}}
+		sender := &mockWebhookSender{
+			sendFn: func(ctx context.Context, request *ports.WebhookRequest) error {
+				if deliveries.leaseUntil.IsZero() {
+					t.Error("expected delivery to be claimed before sending")
+				}
+				return nil
+			},
+		}
+
+		dispatcher := NewWebhookDispatcher(
+			&mockWebhookSubscriptionRepository{subscriptions: []*entities.WebhookSubscription{subscription}},
+			deliveries, sender, &mockLogger{},
+			WebhookDispatcherConfig{ClaimLease: time.Minute},
+		)
+		before := time.Now()
+		err := dispatcher.DispatchPending(context.Background())
+
+		assertNoError(t, err)
+		if lease := deliveries.leaseUntil.Sub(before); lease < time.Minute {
+			t.Errorf("expected a lease of at least 1m, got %v", lease)
+		}
+	})
+
+	t.Run("should keep sending the batch when recording a result fails", func(t *testing.T) {
+		subscription := newWebhookSubscription(t)
+		first := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{}`))
+		second := entities.NewWebhookDelivery(subscription.ID, subscription.ID, WebhookEventProposalStatusChanged, []byte(`{}`))
+		deliveries := &failingUpdateDeliveryRepository{
+			mockWebhookDeliveryRepository: mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{first, second}},
+		}
+		sender := &mockWebhookSender{}
+
+		dispatcher := NewWebhookDispatcher(
+			&mockWebhookSubscriptionRepository{subscriptions: []*entities.WebhookSubscription{subscription}},
+			deliveries, sender, &mockLogger{}, WebhookDispatcherConfig{},
+		)
+		err := dispatcher.DispatchPending(context.Background())
+
+		assertNoError(t, err)
+		if len(sender.sent) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(sender.sent))
+		}
+	})
+}
+
+type failingUpdateDeliveryRepository struct {
+	mockWebhookDeliveryRepository
+}
+
+func (m *failingUpdateDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return errors.New("db down")
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	appErrors "github.com/gabrielaraujr/golang-case/account/internal/application"
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookEventProposalStatusChanged = "proposal.status_changed"
+	DefaultWebhookDeliveriesLimit     = 50
+)
+
+var ErrInvalidDeliveryStatus = errors.New("invalid status filter, expected pending, delivered or dead")
+
+// webhookEnqueuer lets the status change flow schedule webhooks inside its
+// own transaction.
+type webhookEnqueuer interface {
+	Enqueue(ctx context.Context, proposal *entities.Proposal, previous entities.ProposalStatus) error
+}
+
+// WebhookService manages partner subscriptions and the delivery log.
+type WebhookService struct {
+	subscriptions ports.WebhookSubscriptionRepository
+	deliveries    ports.WebhookDeliveryRepository
+}
+
+func NewWebhookService(
+	subscriptions ports.WebhookSubscriptionRepository,
+	deliveries ports.WebhookDeliveryRepository,
+) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+// Subscribe registers a webhook endpoint. The response is the only place the
+// secret is returned.
+func (s *WebhookService) Subscribe(
+	ctx context.Context,
+	req *dto.CreateWebhookRequest,
+) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := entities.NewWebhookSubscription(req.URL, req.Secret)
+	if err != nil {
+		return nil, appErrors.NewInvalidInputError(err)
+	}
+
+	if err := s.subscriptions.Save(ctx, subscription); err != nil {
+		return nil, appErrors.NewInternalError("failed to save webhook subscription", err)
+	}
+
+	response := subscriptionToResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) (*dto.WebhookSubscriptionListResponse, error) {
+	subscriptions, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to list webhook subscriptions", err)
+	}
+
+	response := &dto.WebhookSubscriptionListResponse{Items: make([]*dto.WebhookSubscriptionResponse, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		response.Items = append(response.Items, subscriptionToResponse(subscription))
+	}
+	return response, nil
+}
+
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	req *dto.ListWebhookDeliveriesRequest,
+) (*dto.WebhookDeliveryListResponse, error) {
+	status := entities.WebhookDeliveryStatus(req.Status)
+	if status != "" && !status.IsKnown() {
+		return nil, appErrors.NewInvalidInputError(ErrInvalidDeliveryStatus)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultWebhookDeliveriesLimit
+	}
+	if limit < 0 || limit > MaxListLimit {
+		return nil, appErrors.NewInvalidInputError(ErrInvalidLimit)
+	}
+
+	deliveries, err := s.deliveries.List(ctx, ports.WebhookDeliveryFilter{Status: status, Limit: limit})
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to list webhook deliveries", err)
+	}
+
+	response := &dto.WebhookDeliveryListResponse{Items: make([]*dto.WebhookDeliveryResponse, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Items = append(response.Items, deliveryToResponse(delivery))
+	}
+	return response, nil
+}
+
+// Replay puts a dead delivery back in the queue with a fresh attempt budget.
+func (s *WebhookService) Replay(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := s.deliveries.FindByID(ctx, id)
+	if err != nil && errors.Is(err, domainErrors.ErrWebhookDeliveryNotFound) {
+		return nil, appErrors.NewNotFoundError("webhook delivery")
+	}
+	if err != nil {
+		return nil, appErrors.NewInternalError("failed to get webhook delivery", err)
+	}
+
+	if err := delivery.Replay(); err != nil {
+		return nil, appErrors.NewConflictError("DELIVERY_NOT_DEAD", err)
+	}
+
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return nil, appErrors.NewInternalError("failed to replay webhook delivery", err)
+	}
+
+	return deliveryToResponse(delivery), nil
+}
+
+// Enqueue schedules one delivery per subscription. It runs inside the status
+// change transaction, so a rolled back change never reaches partners.
+func (s *WebhookService) Enqueue(
+	ctx context.Context,
+	proposal *entities.Proposal,
+	previous entities.ProposalStatus,
+) error {
+	subscriptions, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	event := dto.WebhookEvent{
+		ID:         uuid.New(),
+		Type:       WebhookEventProposalStatusChanged,
+		OccurredAt: proposal.UpdatedAt,
+		Data: dto.WebhookEventData{
+			ProposalID:     proposal.ID,
+			PreviousStatus: string(previous),
+			Status:         string(proposal.Status),
+		},
+	}
+	if proposal.Rejection != nil {
+		event.Data.Rejection = &dto.RejectionResponse{
+			Code:    proposal.Rejection.Code,
+			Message: proposal.Rejection.Message,
+		}
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal webhook event: %w", err)
+	}
+
+	for _, subscription := range subscriptions {
+		delivery := entities.NewWebhookDelivery(subscription.ID, proposal.ID, event.Type, payload)
+		if err := s.deliveries.Save(ctx, delivery); err != nil {
+			return fmt.Errorf("save webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+func subscriptionToResponse(subscription *entities.WebhookSubscription) *dto.WebhookSubscriptionResponse {
+	return &dto.WebhookSubscriptionResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+func deliveryToResponse(delivery *entities.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		ProposalID:     delivery.ProposalID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/application/dto"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+func newWebhookSubscription(t *testing.T) *entities.WebhookSubscription {
+	t.Helper()
+	subscription, err := entities.NewWebhookSubscription("https://partner.example.com/hooks", "partner-secret-0001")
+	if err != nil {
+		t.Fatalf("failed to build subscription: %v", err)
+	}
+	return subscription
+}
+
+func TestWebhookService_Subscribe(t *testing.T) {
+	t.Run("should generate secret when none is given", func(t *testing.T) {
+		subscriptions := &mockWebhookSubscriptionRepository{}
+		service := NewWebhookService(subscriptions, &mockWebhookDeliveryRepository{})
+
+		response, err := service.Subscribe(context.Background(), &dto.CreateWebhookRequest{URL: "https://partner.example.com/hooks"})
+
+		assertNoError(t, err)
+		if response.Secret == "" {
+			t.Error("expected generated secret in response")
+		}
+		if len(subscriptions.subscriptions) != 1 {
+			t.Errorf("expected 1 subscription saved, got %d", len(subscriptions.subscriptions))
+		}
+	})
+
+	t.Run("should return INVALID_INPUT for invalid url", func(t *testing.T) {
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, &mockWebhookDeliveryRepository{})
+
+		_, err := service.Subscribe(context.Background(), &dto.CreateWebhookRequest{URL: "ftp://partner.example.com"})
+
+		assertApplicationError(t, err, "INVALID_INPUT", http.StatusBadRequest)
+	})
+
+	t.Run("should return INVALID_INPUT for an internal url", func(t *testing.T) {
+		subscriptions := &mockWebhookSubscriptionRepository{}
+		service := NewWebhookService(subscriptions, &mockWebhookDeliveryRepository{})
+
+		_, err := service.Subscribe(context.Background(), &dto.CreateWebhookRequest{URL: "http://169.254.169.254/latest/meta-data"})
+
+		assertApplicationError(t, err, "INVALID_INPUT", http.StatusBadRequest)
+		if len(subscriptions.subscriptions) != 0 {
+			t.Error("expected subscription not to be saved")
+		}
+	})
+
+	t.Run("should return INVALID_INPUT for short secret", func(t *testing.T) {
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, &mockWebhookDeliveryRepository{})
+
+		_, err := service.Subscribe(context.Background(), &dto.CreateWebhookRequest{
+			URL:    "https://partner.example.com/hooks",
+			Secret: "short",
+		})
+
+		assertApplicationError(t, err, "INVALID_INPUT", http.StatusBadRequest)
+	})
+}
+
+func TestWebhookService_Enqueue(t *testing.T) {
+	t.Run("should create one delivery per subscription", func(t *testing.T) {
+		subscriptions := &mockWebhookSubscriptionRepository{
+			subscriptions: []*entities.WebhookSubscription{newWebhookSubscription(t), newWebhookSubscription(t)},
+		}
+		deliveries := &mockWebhookDeliveryRepository{}
+		service := NewWebhookService(subscriptions, deliveries)
+
+		proposal := newProposalWithStatus(entities.StatusAnalyzing)
+		assertNoError(t, proposal.RejectWithReason(entities.RejectionReason{Code: "SALARY_BELOW_MINIMUM", Message: "salary too low"}))
+
+		err := service.Enqueue(context.Background(), proposal, entities.StatusAnalyzing)
+
+		assertNoError(t, err)
+		if len(deliveries.deliveries) != 2 {
+			t.Fatalf("expected 2 deliveries, got %d", len(deliveries.deliveries))
+		}
+
+		var event dto.WebhookEvent
+		if err := json.Unmarshal(deliveries.deliveries[0].Payload, &event); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if event.Type != WebhookEventProposalStatusChanged {
+			t.Errorf("expected type %q, got %q", WebhookEventProposalStatusChanged, event.Type)
+		}
+		if event.Data.PreviousStatus != "analyzing" || event.Data.Status != "rejected" {
+			t.Errorf("unexpected transition %q -> %q", event.Data.PreviousStatus, event.Data.Status)
+		}
+		if event.Data.Rejection == nil || event.Data.Rejection.Code != "SALARY_BELOW_MINIMUM" {
+			t.Errorf("expected rejection reason in payload, got %+v", event.Data.Rejection)
+		}
+	})
+
+	t.Run("should do nothing without subscriptions", func(t *testing.T) {
+		deliveries := &mockWebhookDeliveryRepository{}
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, deliveries)
+
+		err := service.Enqueue(context.Background(), newProposalWithStatus(entities.StatusAccepted), entities.StatusAnalyzing)
+
+		assertNoError(t, err)
+		if len(deliveries.deliveries) != 0 {
+			t.Errorf("expected no deliveries, got %d", len(deliveries.deliveries))
+		}
+	})
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	t.Run("should reschedule dead delivery", func(t *testing.T) {
+		delivery := entities.NewWebhookDelivery(uuid.New(), uuid.New(), WebhookEventProposalStatusChanged, []byte(`{}`))
+		delivery.Status = entities.WebhookDeliveryDead
+		delivery.Attempts = 8
+		deliveries := &mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}}
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, deliveries)
+
+		response, err := service.Replay(context.Background(), delivery.ID)
+
+		assertNoError(t, err)
+		if response.Status != string(entities.WebhookDeliveryPending) {
+			t.Errorf("expected status pending, got %q", response.Status)
+		}
+		if response.Attempts != 0 {
+			t.Errorf("expected attempts to reset, got %d", response.Attempts)
+		}
+		if len(deliveries.updated) != 1 {
+			t.Errorf("expected 1 update, got %d", len(deliveries.updated))
+		}
+	})
+
+	t.Run("should return conflict for delivery that is not dead", func(t *testing.T) {
+		delivery := entities.NewWebhookDelivery(uuid.New(), uuid.New(), WebhookEventProposalStatusChanged, []byte(`{}`))
+		deliveries := &mockWebhookDeliveryRepository{deliveries: []*entities.WebhookDelivery{delivery}}
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, deliveries)
+
+		_, err := service.Replay(context.Background(), delivery.ID)
+
+		assertApplicationError(t, err, "DELIVERY_NOT_DEAD", http.StatusConflict)
+	})
+
+	t.Run("should return NOT_FOUND for unknown delivery", func(t *testing.T) {
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, &mockWebhookDeliveryRepository{})
+
+		_, err := service.Replay(context.Background(), uuid.New())
+
+		assertApplicationError(t, err, "NOT_FOUND", http.StatusNotFound)
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	t.Run("should return INVALID_INPUT for unknown status", func(t *testing.T) {
+		service := NewWebhookService(&mockWebhookSubscriptionRepository{}, &mockWebhookDeliveryRepository{})
+
+		_, err := service.ListDeliveries(context.Background(), &dto.ListWebhookDeliveriesRequest{Status: "failed"})
+
+		assertApplicationError(t, err, "INVALID_INPUT", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+// MinApplicantAge is the legal age to open an account.
+const MinApplicantAge = 18
+
+// AgePolicy sets the age range accepted for new proposals. MaxAge is
+// optional; zero means no upper bound.
+type AgePolicy struct {
+	MaxAge int
+}
+
+// Check validates birthDate against the policy on the date of now.
+func (p AgePolicy) Check(birthDate, now time.Time) error {
+	if birthDate.After(now) {
+		return errors.ErrBirthDateInFuture
+	}
+
+	age := AgeAt(birthDate, now)
+	if age < MinApplicantAge {
+		return errors.ErrApplicantUnderage
+	}
+	if p.MaxAge > 0 && age > p.MaxAge {
+		return errors.ErrApplicantAboveMaxAge
+	}
+	return nil
+}
+
+// AgeAt returns the age in full years on the date of now. People born on
+// February 29 turn a year older on March 1 in non-leap years.
+func AgeAt(birthDate, now time.Time) int {
+	now = now.In(birthDate.Location())
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
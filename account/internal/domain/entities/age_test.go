@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAgeAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		birthDate time.Time
+		now       time.Time
+		want      int
+	}{
+		{name: "day before birthday", birthDate: date(2000, 6, 15), now: date(2018, 6, 14), want: 17},
+		{name: "on birthday", birthDate: date(2000, 6, 15), now: date(2018, 6, 15), want: 18},
+		{name: "later month", birthDate: date(2000, 6, 15), now: date(2018, 7, 1), want: 18},
+		{name: "leap day before march", birthDate: date(2000, 2, 29), now: date(2018, 2, 28), want: 17},
+		{name: "leap day in march", birthDate: date(2000, 2, 29), now: date(2018, 3, 1), want: 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AgeAt(tt.birthDate, tt.now); got != tt.want {
+				t.Errorf("AgeAt(%v, %v) = %d, want %d", tt.birthDate, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgePolicyCheck(t *testing.T) {
+	now := date(2025, 3, 10)
+	tests := []struct {
+		name      string
+		policy    AgePolicy
+		birthDate time.Time
+		wantErr   error
+	}{
+		{name: "turns 18 today", birthDate: date(2007, 3, 10)},
+		{name: "17 years old", birthDate: date(2007, 3, 11), wantErr: domainErrors.ErrApplicantUnderage},
+		{name: "future", birthDate: date(2025, 3, 11), wantErr: domainErrors.ErrBirthDateInFuture},
+		{name: "no upper bound", birthDate: date(1925, 1, 1)},
+		{name: "last day at max age", policy: AgePolicy{MaxAge: 80}, birthDate: date(1944, 3, 11)},
+		{name: "above max age", policy: AgePolicy{MaxAge: 80}, birthDate: date(1944, 3, 10), wantErr: domainErrors.ErrApplicantAboveMaxAge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.birthDate, now)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+type CardStatus string
+
+const (
+	CardStatusActive CardStatus = "active"
+)
+
+const (
+	panLength      = 16
+	cardValidYears = 5
+)
+
+// Card is a virtual card linked to a customer account. The full PAN is only
+// known while issuing; afterwards only its masked form and token are kept.
+type Card struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	ProposalID  uuid.UUID
+	Token       string
+	MaskedPAN   string
+	ExpiryMonth int
+	ExpiryYear  int
+	Status      CardStatus
+	CreatedAt   time.Time
+}
+
+// GeneratePAN returns a random Luhn-valid PAN starting with bin.
+func GeneratePAN(bin string) (string, error) {
+	if len(bin) < 6 || len(bin) > 8 || !isDigits(bin) {
+		return "", errors.ErrInvalidCardBIN
+	}
+
+	var b strings.Builder
+	b.WriteString(bin)
+	for b.Len() < panLength-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+
+	partial := b.String()
+	return partial + string(rune('0'+LuhnCheckDigit(partial))), nil
+}
+
+// NewVirtualCard issues a card for the account, keeping the PAN only as
+// token and masked value.
+func NewVirtualCard(account *CustomerAccount, pan, token string) *Card {
+	now := time.Now()
+	expiry := now.AddDate(cardValidYears, 0, 0)
+	return &Card{
+		ID:          uuid.New(),
+		AccountID:   account.ID,
+		ProposalID:  account.ProposalID,
+		Token:       token,
+		MaskedPAN:   MaskPAN(pan),
+		ExpiryMonth: int(expiry.Month()),
+		ExpiryYear:  expiry.Year(),
+		Status:      CardStatusActive,
+		CreatedAt:   now,
+	}
+}
+
+// MaskPAN keeps the first six and last four digits.
+func MaskPAN(pan string) string {
+	if len(pan) <= 10 {
+		return strings.Repeat("*", len(pan))
+	}
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+// LuhnCheckDigit returns the digit that makes digits + check Luhn-valid.
+func LuhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+func IsLuhnValid(number string) bool {
+	if len(number) < 2 || !isDigits(number) {
+		return false
+	}
+	return LuhnCheckDigit(number[:len(number)-1]) == int(number[len(number)-1]-'0')
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
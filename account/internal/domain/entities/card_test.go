@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLuhn(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"4111111111111111", true},
+		{"5555555555554444", true},
+		{"79927398713", true},
+		{"4111111111111112", false},
+		{"4111-1111-1111-1111", false},
+		{"0", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsLuhnValid(tt.number); got != tt.valid {
+			t.Errorf("IsLuhnValid(%q) = %v, want %v", tt.number, got, tt.valid)
+		}
+	}
+}
+
+func TestGeneratePAN(t *testing.T) {
+	t.Run("should generate Luhn-valid PAN with BIN prefix", func(t *testing.T) {
+		for range 20 {
+			pan, err := GeneratePAN("539999")
+			assertNoError(t, err)
+			if len(pan) != 16 || !strings.HasPrefix(pan, "539999") {
+				t.Fatalf("unexpected PAN %q", pan)
+			}
+			if !IsLuhnValid(pan) {
+				t.Fatalf("expected Luhn-valid PAN, got %q", pan)
+			}
+		}
+	})
+
+	t.Run("should reject invalid BIN", func(t *testing.T) {
+		_, err := GeneratePAN("53a9")
+		assertError(t, err)
+	})
+}
+
+func TestNewVirtualCard(t *testing.T) {
+	account := NewCustomerAccount(uuid.New(), "0001", 42)
+	card := NewVirtualCard(account, "5399991234567890", "tok_abc")
+
+	if card.MaskedPAN != "539999******7890" {
+		t.Errorf("expected masked PAN 539999******7890, got %q", card.MaskedPAN)
+	}
+	if card.Token != "tok_abc" {
+		t.Errorf("expected token to be kept, got %q", card.Token)
+	}
+	if card.AccountID != account.ID || card.ProposalID != account.ProposalID {
+		t.Error("expected card to be linked to account and proposal")
+	}
+	if card.Status != CardStatusActive {
+		t.Errorf("expected active card, got %q", card.Status)
+	}
+}
+
+func TestCustomerAccount(t *testing.T) {
+	t.Run("should pad account number and compute check digit", func(t *testing.T) {
+		account := NewCustomerAccount(uuid.New(), "0001", 42)
+
+		if account.Number != "00000042" {
+			t.Errorf("expected number 00000042, got %q", account.Number)
+		}
+		if account.CheckDigit != AccountCheckDigit("0001", "00000042") {
+			t.Errorf("unexpected check digit %q", account.CheckDigit)
+		}
+		if account.FormattedNumber() != "00000042-"+account.CheckDigit {
+			t.Errorf("unexpected formatted number %q", account.FormattedNumber())
+		}
+	})
+
+	t.Run("should compute modulo 11 check digit", func(t *testing.T) {
+		tests := []struct {
+			branch, number, want string
+		}{
+			// Weights cycle 2..9 from the right, so the branch digit gets 2 again.
+			// 0001 00000001: 1*2 + 1*2 = 4 -> 11 - 4 = 7
+			{"0001", "00000001", "7"},
+			// 0001 00000010: 1*3 + 1*2 = 5 -> 11 - 5 = 6
+			{"0001", "00000010", "6"},
+			// 0001 00000005: 5*2 + 1*2 = 12 -> 11 - 1 = 10 -> 0
+			{"0001", "00000005", "0"},
+			// 0001 00000009: 9*2 + 1*2 = 20 -> 11 - 9 = 2
+			{"0001", "00000009", "2"},
+		}
+
+		for _, tt := range tests {
+			if got := AccountCheckDigit(tt.branch, tt.number); got != tt.want {
+				t.Errorf("AccountCheckDigit(%q, %q) = %q, want %q", tt.branch, tt.number, got, tt.want)
+			}
+		}
+	})
+}
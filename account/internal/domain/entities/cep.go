@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"strings"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+const cepLength = 8
+
+// CEP is a Brazilian postal code, kept as its 8 digits.
+type CEP string
+
+var cepPunctuation = strings.NewReplacer("-", "", ".", "", " ", "")
+
+// ParseCEP accepts a CEP with or without punctuation ("01234-567").
+func ParseCEP(raw string) (CEP, error) {
+	digits := cepPunctuation.Replace(strings.TrimSpace(raw))
+	if digits == "" {
+		return "", errors.ErrZipCodeRequired
+	}
+	if len(digits) != cepLength || !isDigits(digits) {
+		return "", errors.ErrZipCodeInvalid
+	}
+	return CEP(digits), nil
+}
+
+func (c CEP) String() string {
+	return string(c)
+}
+
+// Formatted renders the CEP as 01234-567.
+func (c CEP) Formatted() string {
+	s := string(c)
+	if len(s) != cepLength {
+		return s
+	}
+	return s[:5] + "-" + s[5:]
+}
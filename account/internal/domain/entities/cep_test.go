@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestParseCEP(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CEP
+		wantErr error
+	}{
+		{name: "digits only", input: "01234567", want: "01234567"},
+		{name: "formatted", input: "01234-567", want: "01234567"},
+		{name: "dotted", input: "01.234-567", want: "01234567"},
+		{name: "empty", input: "", wantErr: domainErrors.ErrZipCodeRequired},
+		{name: "too short", input: "1234-567", wantErr: domainErrors.ErrZipCodeInvalid},
+		{name: "too long", input: "012345678", wantErr: domainErrors.ErrZipCodeInvalid},
+		{name: "letters", input: "0123A-567", wantErr: domainErrors.ErrZipCodeInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCEP(tt.input)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got != tt.want {
+				t.Errorf("ParseCEP(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCEPFormatted(t *testing.T) {
+	if got := CEP("01234567").Formatted(); got != "01234-567" {
+		t.Errorf("Formatted() = %q, want 01234-567", got)
+	}
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"strings"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+const cpfLength = 11
+
+// CPF is a validated Brazilian taxpayer number, kept as its 11 digits.
+// The same rules are implemented in risk-analysis/internal/domain/cpf.go.
+type CPF string
+
+var cpfPunctuation = strings.NewReplacer(".", "", "-", "", " ", "")
+
+// ParseCPF accepts a CPF with or without punctuation ("123.456.789-09") and
+// checks its length, repeated digit sequences and both check digits.
+func ParseCPF(raw string) (CPF, error) {
+	digits := cpfPunctuation.Replace(strings.TrimSpace(raw))
+	if digits == "" {
+		return "", errors.ErrCPFRequired
+	}
+	if !isDigits(digits) {
+		return "", errors.ErrCPFInvalidFormat
+	}
+	if len(digits) != cpfLength {
+		return "", errors.ErrCPFInvalidLength
+	}
+	if strings.Count(digits, digits[:1]) == cpfLength {
+		return "", errors.ErrCPFRepeatedDigits
+	}
+
+	first := cpfCheckDigit(digits[:9])
+	second := cpfCheckDigit(digits[:9] + string(first))
+	if digits[9] != first || digits[10] != second {
+		return "", errors.ErrCPFInvalidCheckDigits
+	}
+	return CPF(digits), nil
+}
+
+func (c CPF) String() string {
+	return string(c)
+}
+
+// Formatted renders the CPF as 123.456.789-09.
+func (c CPF) Formatted() string {
+	s := string(c)
+	if len(s) != cpfLength {
+		return s
+	}
+	return s[:3] + "." + s[3:6] + "." + s[6:9] + "-" + s[9:]
+}
+
+// cpfCheckDigit computes the modulo 11 check digit of digits, weighting
+// them from len+1 down to 2.
+func cpfCheckDigit(digits string) byte {
+	sum := 0
+	weight := len(digits) + 1
+	for i := range len(digits) {
+		sum += int(digits[i]-'0') * weight
+		weight--
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
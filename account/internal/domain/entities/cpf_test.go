@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestParseCPF(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CPF
+		wantErr error
+	}{
+		{name: "digits only", input: "12345678909", want: "12345678909"},
+		{name: "formatted", input: "123.456.789-09", want: "12345678909"},
+		{name: "surrounding spaces", input: " 529.982.247-25 ", want: "52998224725"},
+		{name: "check digit zero", input: "98765432100", want: "98765432100"},
+		{name: "empty", input: "", wantErr: domainErrors.ErrCPFRequired},
+		{name: "only punctuation", input: "..-", wantErr: domainErrors.ErrCPFRequired},
+		{name: "letters", input: "1234567890a", wantErr: domainErrors.ErrCPFInvalidFormat},
+		{name: "slash", input: "123/456/789-09", wantErr: domainErrors.ErrCPFInvalidFormat},
+		{name: "too short", input: "123456789", wantErr: domainErrors.ErrCPFInvalidLength},
+		{name: "too long", input: "123456789090", wantErr: domainErrors.ErrCPFInvalidLength},
+		{name: "repeated ones", input: "11111111111", wantErr: domainErrors.ErrCPFRepeatedDigits},
+		{name: "repeated zeros formatted", input: "000.000.000-00", wantErr: domainErrors.ErrCPFRepeatedDigits},
+		{name: "wrong first check digit", input: "12345678919", wantErr: domainErrors.ErrCPFInvalidCheckDigits},
+		{name: "wrong second check digit", input: "12345678901", wantErr: domainErrors.ErrCPFInvalidCheckDigits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCPF(tt.input)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got != tt.want {
+				t.Errorf("ParseCPF(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCPFFormatted(t *testing.T) {
+	if got := CPF("12345678909").Formatted(); got != "123.456.789-09" {
+		t.Errorf("Formatted() = %q, want 123.456.789-09", got)
+	}
+}
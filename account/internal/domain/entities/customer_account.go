@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const accountNumberDigits = 8
+
+// CustomerAccount is the checking account opened for an approved proposal.
+type CustomerAccount struct {
+	ID         uuid.UUID
+	ProposalID uuid.UUID
+	Branch     string
+	Number     string
+	CheckDigit string
+	CreatedAt  time.Time
+}
+
+// NewCustomerAccount builds the account from a sequential number, padded to
+// a fixed width, and computes its check digit.
+func NewCustomerAccount(proposalID uuid.UUID, branch string, sequence int64) *CustomerAccount {
+	number := fmt.Sprintf("%0*d", accountNumberDigits, sequence)
+	return &CustomerAccount{
+		ID:         uuid.New(),
+		ProposalID: proposalID,
+		Branch:     branch,
+		Number:     number,
+		CheckDigit: AccountCheckDigit(branch, number),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// FormattedNumber renders the account the way it is shown to customers,
+// e.g. "12345678-9".
+func (a *CustomerAccount) FormattedNumber() string {
+	return a.Number + "-" + a.CheckDigit
+}
+
+// AccountCheckDigit computes the modulo 11 check digit over branch and
+// number, with weights 2..9 from right to left. Results of 10 and 11 map to
+// "0".
+func AccountCheckDigit(branch, number string) string {
+	digits := branch + number
+	sum, weight := 0, 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	digit := 11 - sum%11
+	if digit >= 10 {
+		digit = 0
+	}
+	return fmt.Sprint(digit)
+}
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DocumentType string
+
+const (
+	DocumentIdentity      DocumentType = "identity"
+	DocumentProofOfIncome DocumentType = "proof_of_income"
+)
+
+// RequiredDocumentTypes must all be uploaded before the proposal is sent to
+// risk-analysis.
+var RequiredDocumentTypes = []DocumentType{DocumentIdentity, DocumentProofOfIncome}
+
+func (t DocumentType) IsKnown() bool {
+	switch t {
+	case DocumentIdentity, DocumentProofOfIncome:
+		return true
+	}
+	return false
+}
+
+// allowedContentTypes are the sniffed MIME types accepted for documents.
+var allowedContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// DefaultMaxDocumentSize is the largest file accepted when DocumentPolicy
+// does not set one.
+const DefaultMaxDocumentSize = 10 << 20
+
+// DocumentPolicy limits the files accepted as proposal documents.
+type DocumentPolicy struct {
+	MaxSize int64
+}
+
+func (p DocumentPolicy) maxSize() int64 {
+	if p.MaxSize <= 0 {
+		return DefaultMaxDocumentSize
+	}
+	return p.MaxSize
+}
+
+// Document is a file uploaded by the applicant for the document analysis.
+// The content lives in the document store under StorageKey; SHA256 is the
+// hex digest of the content. Width and Height are only set for images and
+// Pages for PDFs; they are zero when the file could not be read.
+type Document struct {
+	ID          uuid.UUID
+	ProposalID  uuid.UUID
+	Type        DocumentType
+	FileName    string
+	ContentType string
+	Size        int64
+	SHA256      string
+	Width       int
+	Height      int
+	Pages       int
+	StorageKey  string
+	UploadedAt  time.Time
+}
+
+// NewDocument validates the file and fingerprints it. The content type is
+// sniffed from the content; the one declared by the client is ignored.
+func NewDocument(
+	proposalID uuid.UUID,
+	docType DocumentType,
+	fileName string,
+	content []byte,
+	policy DocumentPolicy,
+) (*Document, error) {
+	if !docType.IsKnown() {
+		return nil, errors.ErrDocumentTypeInvalid
+	}
+	if len(content) == 0 {
+		return nil, errors.ErrDocumentEmpty
+	}
+	if int64(len(content)) > policy.maxSize() {
+		return nil, errors.ErrDocumentTooLarge
+	}
+	contentType := http.DetectContentType(content)
+	if !allowedContentTypes[contentType] {
+		return nil, errors.ErrDocumentContentTypeNotAllowed
+	}
+
+	id := uuid.New()
+	sum := sha256.Sum256(content)
+	document := &Document{
+		ID:          id,
+		ProposalID:  proposalID,
+		Type:        docType,
+		FileName:    path.Base(strings.ReplaceAll(fileName, `\`, "/")),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		StorageKey:  fmt.Sprintf("proposals/%s/%s", proposalID, id),
+		UploadedAt:  time.Now(),
+	}
+	if contentType == "application/pdf" {
+		document.Pages = pdfPageCount(content)
+	} else {
+		document.Width, document.Height = imageDimensions(content)
+	}
+	return document, nil
+}
+
+// HasRequiredDocuments reports whether documents include every type in
+// RequiredDocumentTypes.
+func HasRequiredDocuments(documents []*Document) bool {
+	uploaded := make(map[DocumentType]bool, len(documents))
+	for _, document := range documents {
+		uploaded[document.Type] = true
+	}
+	for _, docType := range RequiredDocumentTypes {
+		if !uploaded[docType] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	_ "image/jpeg" // registers the JPEG decoder for image.DecodeConfig
+	_ "image/png"  // registers the PNG decoder for image.DecodeConfig
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// maxInflatedBytes bounds the PDF streams inflated while counting pages,
+// across the whole file, so compressed bombs cannot exhaust memory or CPU.
+const maxInflatedBytes = 8 << 20
+
+var (
+	pdfPagesCount = regexp.MustCompile(`/Type\s*/Pages\b[^>]*?/Count\s+(\d+)|/Count\s+(\d+)[^>]*?/Type\s*/Pages\b`)
+	pdfPage       = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfStream     = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+)
+
+// imageDimensions reads the size from the image header. Unreadable images
+// report zero.
+func imageDimensions(content []byte) (width, height int) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return 0, 0
+	}
+	return config.Width, config.Height
+}
+
+// pdfPageCount counts the pages of a PDF without a full parser. It takes the
+// /Count of the page tree root, the largest one, and falls back to counting
+// page objects. Objects packed in compressed object streams are inflated
+// first, until maxInflatedBytes is spent; later streams are skipped.
+// Unreadable files report zero.
+func pdfPageCount(content []byte) int {
+	count, pages := countPDFPages(content)
+
+	budget := int64(maxInflatedBytes)
+	for _, match := range pdfStream.FindAllSubmatchIndex(content, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(content[match[2]:match[3]]))
+		if err != nil {
+			continue
+		}
+		inflated, _ := io.ReadAll(io.LimitReader(reader, budget+1))
+		reader.Close()
+		if int64(len(inflated)) > budget {
+			break
+		}
+		budget -= int64(len(inflated))
+
+		streamCount, streamPages := countPDFPages(inflated)
+		count = max(count, streamCount)
+		pages += streamPages
+	}
+
+	if count > 0 {
+		return count
+	}
+	return pages
+}
+
+// countPDFPages returns the largest page tree /Count and the number of page
+// objects found in source.
+func countPDFPages(source []byte) (count, pages int) {
+	for _, match := range pdfPagesCount.FindAllSubmatch(source, -1) {
+		value := match[1]
+		if len(value) == 0 {
+			value = match[2]
+		}
+		if n, err := strconv.Atoi(string(value)); err == nil && n > count {
+			count = n
+		}
+	}
+	return count, len(pdfPage.FindAll(source, -1))
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/png"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestImageDimensions(t *testing.T) {
+	t.Run("should read the size from the image header", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 640, 480))); err != nil {
+			t.Fatal(err)
+		}
+
+		width, height := imageDimensions(buf.Bytes())
+		if width != 640 || height != 480 {
+			t.Errorf("expected 640x480, got %dx%d", width, height)
+		}
+	})
+
+	t.Run("should report zero for truncated images", func(t *testing.T) {
+		width, height := imageDimensions(pngContent)
+		if width != 0 || height != 0 {
+			t.Errorf("expected 0x0, got %dx%d", width, height)
+		}
+	})
+}
+
+func TestPDFPageCount(t *testing.T) {
+	compressed := func(s string) string {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write([]byte(s))
+		w.Close()
+		return "stream\n" + buf.String() + "\nendstream"
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{"page tree count", "%PDF-1.4\n2 0 obj\n<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>\nendobj", 2},
+		{"count before type", "%PDF-1.4\n2 0 obj\n<< /Count 4 /Kids [] /Type /Pages >>\nendobj", 4},
+		{"nested page trees", "<< /Type /Pages /Count 5 >> << /Type /Pages /Count 2 >>", 5},
+		{"page objects only", "<< /Type /Page >> << /Type /Page /Parent 2 0 R >> << /Type /Catalog >>", 2},
+		{"compressed object stream", "%PDF-1.5\n5 0 obj\n<< /Type /ObjStm /Filter /FlateDecode >>\n" + compressed("<< /Type /Pages /Count 3 >>") + "\nendobj", 3},
+		{"no pages", "%PDF-1.4\n1 0 obj\n<<>>\nendobj\n%%EOF", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pdfPageCount([]byte(tt.content)); got != tt.expected {
+				t.Errorf("expected %d pages, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPDFPageCountInflateLimit(t *testing.T) {
+	var bomb bytes.Buffer
+	w := zlib.NewWriter(&bomb)
+	w.Write(make([]byte, 4<<20))
+	w.Close()
+	stream := "stream\n" + bomb.String() + "\nendstream\n"
+
+	// 300 streams of 4 MiB each would inflate to 1.2 GiB without a shared
+	// limit.
+	content := []byte("%PDF-1.5\n" + strings.Repeat(stream, 300))
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	pages := pdfPageCount(content)
+	runtime.ReadMemStats(&after)
+
+	if pages != 0 {
+		t.Errorf("expected 0 pages, got %d", pages)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<20 {
+		t.Errorf("expected inflating to stop at the shared limit, allocated %d MiB", allocated>>20)
+	}
+}
+
+func TestNewDocumentInspection(t *testing.T) {
+	t.Run("should count pages of PDFs", func(t *testing.T) {
+		content := []byte("%PDF-1.4\n1 0 obj\n<< /Type /Pages /Count 2 >>\nendobj\n%%EOF")
+		document, err := NewDocument(NewProposalBuilder().Build().ID, DocumentProofOfIncome, "holerite.pdf", content, DocumentPolicy{})
+		assertNoError(t, err)
+		if document.Pages != 2 || document.Width != 0 {
+			t.Errorf("expected 2 pages and no dimensions, got %+v", document)
+		}
+	})
+
+	t.Run("should keep the dimensions of images", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 800, 600))); err != nil {
+			t.Fatal(err)
+		}
+		document, err := NewDocument(NewProposalBuilder().Build().ID, DocumentIdentity, "rg.png", buf.Bytes(), DocumentPolicy{})
+		assertNoError(t, err)
+		if document.Width != 800 || document.Height != 600 || document.Pages != 0 {
+			t.Errorf("expected 800x600 without pages, got %+v", document)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	pdfContent = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n%%EOF")
+	pngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+)
+
+func TestNewDocument(t *testing.T) {
+	proposalID := uuid.New()
+
+	t.Run("should sniff the content type and hash the content", func(t *testing.T) {
+		document, err := NewDocument(proposalID, DocumentIdentity, "rg.png", pngContent, DocumentPolicy{})
+
+		assertNoError(t, err)
+		if document.ContentType != "image/png" {
+			t.Errorf("expected image/png, got %q", document.ContentType)
+		}
+		if len(document.SHA256) != 64 || document.Size != int64(len(pngContent)) {
+			t.Errorf("expected a hex SHA-256 and the content size, got %q and %d", document.SHA256, document.Size)
+		}
+		if !strings.HasPrefix(document.StorageKey, "proposals/"+proposalID.String()+"/") {
+			t.Errorf("expected storage key under the proposal, got %q", document.StorageKey)
+		}
+	})
+
+	t.Run("should hash equal content equally", func(t *testing.T) {
+		first, _ := NewDocument(proposalID, DocumentIdentity, "a.pdf", pdfContent, DocumentPolicy{})
+		second, _ := NewDocument(uuid.New(), DocumentProofOfIncome, "b.pdf", pdfContent, DocumentPolicy{})
+
+		if first.SHA256 != second.SHA256 {
+			t.Errorf("expected equal hashes, got %q and %q", first.SHA256, second.SHA256)
+		}
+	})
+
+	t.Run("should keep only the base name of the file", func(t *testing.T) {
+		document, _ := NewDocument(proposalID, DocumentIdentity, `C:\Users\me\rg.pdf`, pdfContent, DocumentPolicy{})
+
+		if document.FileName != "rg.pdf" {
+			t.Errorf("expected rg.pdf, got %q", document.FileName)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		docType DocumentType
+		content []byte
+		policy  DocumentPolicy
+		want    error
+	}{
+		{"unknown type", "selfie", pdfContent, DocumentPolicy{}, domainErrors.ErrDocumentTypeInvalid},
+		{"empty file", DocumentIdentity, nil, DocumentPolicy{}, domainErrors.ErrDocumentEmpty},
+		{"file over the limit", DocumentIdentity, pdfContent, DocumentPolicy{MaxSize: 10}, domainErrors.ErrDocumentTooLarge},
+		{"text file", DocumentIdentity, []byte("just some text"), DocumentPolicy{}, domainErrors.ErrDocumentContentTypeNotAllowed},
+		{"html declared as pdf", DocumentIdentity, []byte("<html><body>x</body></html>"), DocumentPolicy{}, domainErrors.ErrDocumentContentTypeNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			_, err := NewDocument(proposalID, tt.docType, "file.pdf", tt.content, tt.policy)
+			assertErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("should apply the default size limit", func(t *testing.T) {
+		content := append(bytes.Clone(pdfContent), make([]byte, DefaultMaxDocumentSize)...)
+		_, err := NewDocument(proposalID, DocumentIdentity, "big.pdf", content, DocumentPolicy{})
+		assertErrorIs(t, err, domainErrors.ErrDocumentTooLarge)
+	})
+}
+
+func TestHasRequiredDocuments(t *testing.T) {
+	identity := &Document{Type: DocumentIdentity}
+	income := &Document{Type: DocumentProofOfIncome}
+
+	assertBool(t, !HasRequiredDocuments(nil), "expected no documents to be incomplete")
+	assertBool(t, !HasRequiredDocuments([]*Document{identity, identity}), "expected identity only to be incomplete")
+	assertBool(t, HasRequiredDocuments([]*Document{income, identity}), "expected identity and income to be complete")
+}
+
+func TestProposalAttachDocument(t *testing.T) {
+	t.Run("should accept documents while pending", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertNoError(t, p.AttachDocument())
+	})
+
+	t.Run("should return error once analysis started", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertErrorIs(t, p.AttachDocument(), domainErrors.ErrOnlyPendingCanReceiveDocuments)
+	})
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"net/mail"
+	"strings"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+// Email is a single RFC 5322 address without a display name. The domain part
+// is kept in lower case; the local part is kept as sent.
+type Email string
+
+// ParseEmail accepts a bare address ("john@example.com"). Display names
+// ("John <john@example.com>") and lists are rejected.
+func ParseEmail(raw string) (Email, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", errors.ErrEmailRequired
+	}
+
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" || addr.Address != value {
+		return "", errors.ErrEmailInvalid
+	}
+
+	at := strings.LastIndex(addr.Address, "@")
+	return Email(addr.Address[:at] + strings.ToLower(addr.Address[at:])), nil
+}
+
+func (e Email) String() string {
+	return string(e)
+}
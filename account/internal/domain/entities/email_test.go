@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestParseEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Email
+		wantErr error
+	}{
+		{name: "simple", input: "john@example.com", want: "john@example.com"},
+		{name: "plus tag and subdomain", input: "john+card@mail.example.com.br", want: "john+card@mail.example.com.br"},
+		{name: "upper case domain", input: "John.Doe@Example.COM", want: "John.Doe@example.com"},
+		{name: "surrounding spaces", input: " john@example.com ", want: "john@example.com"},
+		{name: "empty", input: "", wantErr: domainErrors.ErrEmailRequired},
+		{name: "blank", input: "   ", wantErr: domainErrors.ErrEmailRequired},
+		{name: "missing at", input: "john.example.com", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "missing domain", input: "john@", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "missing local part", input: "@example.com", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "two at signs", input: "john@doe@example.com", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "inner space", input: "john doe@example.com", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "display name", input: "John <john@example.com>", wantErr: domainErrors.ErrEmailInvalid},
+		{name: "address list", input: "john@example.com, jane@example.com", wantErr: domainErrors.ErrEmailInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEmail(tt.input)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got != tt.want {
+				t.Errorf("ParseEmail(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
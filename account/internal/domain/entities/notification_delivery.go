@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliverySent   DeliveryStatus = "sent"
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// NotificationDelivery is the log entry of a message sent to the customer.
+type NotificationDelivery struct {
+	ID         uuid.UUID
+	ProposalID uuid.UUID
+	Channel    string
+	Recipient  string
+	Template   string
+	Status     DeliveryStatus
+	Error      string
+	CreatedAt  time.Time
+}
+
+func NewNotificationDelivery(proposalID uuid.UUID, channel, recipient, template string, sendErr error) *NotificationDelivery {
+	delivery := &NotificationDelivery{
+		ID:         uuid.New(),
+		ProposalID: proposalID,
+		Channel:    channel,
+		Recipient:  recipient,
+		Template:   template,
+		Status:     DeliverySent,
+		CreatedAt:  time.Now(),
+	}
+	if sendErr != nil {
+		delivery.Status = DeliveryFailed
+		delivery.Error = sendErr.Error()
+	}
+	return delivery
+}
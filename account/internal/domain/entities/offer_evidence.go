@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfferDecision string
+
+const (
+	OfferDecisionAccepted OfferDecision = "accepted"
+	OfferDecisionDeclined OfferDecision = "declined"
+)
+
+// OfferEvidence records how and when the customer answered an offer. It is
+// kept as proof of acceptance of the card terms. IPAddress is the address
+// the request came from; ForwardedFor is the X-Forwarded-For header, only
+// kept when that address is a trusted proxy.
+type OfferEvidence struct {
+	ID           uuid.UUID
+	ProposalID   uuid.UUID
+	Decision     OfferDecision
+	TermsVersion string
+	IPAddress    string
+	ForwardedFor string
+	UserAgent    string
+	DecidedAt    time.Time
+}
+
+func NewOfferEvidence(
+	proposalID uuid.UUID,
+	decision OfferDecision,
+	termsVersion string,
+	ipAddress string,
+	forwardedFor string,
+	userAgent string,
+) *OfferEvidence {
+	return &OfferEvidence{
+		ID:           uuid.New(),
+		ProposalID:   proposalID,
+		Decision:     decision,
+		TermsVersion: termsVersion,
+		IPAddress:    ipAddress,
+		ForwardedFor: forwardedFor,
+		UserAgent:    userAgent,
+		DecidedAt:    time.Now(),
+	}
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is an event waiting to be relayed to the queue. It is written
+// in the same transaction as the aggregate change that produced it.
+type OutboxMessage struct {
+	ID            uuid.UUID
+	Destination   string
+	AggregateID   uuid.UUID
+	EventType     string
+	Payload       []byte
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// NewOutboxMessage encodes event for the queue named by destination.
+func NewOutboxMessage(destination string, event events.DomainEvent) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &OutboxMessage{
+		ID:            uuid.New(),
+		Destination:   destination,
+		AggregateID:   event.AggregateID(),
+		EventType:     event.EventName(),
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+func (m *OutboxMessage) MarkSent() {
+	now := time.Now()
+	m.SentAt = &now
+	m.LastError = ""
+}
+
+func (m *OutboxMessage) MarkFailed(err error, nextAttemptAt time.Time) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = nextAttemptAt
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"strings"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+const brazilCountryCode = "55"
+
+// Phone is a Brazilian phone number in E.164 format (+5511987654321).
+type Phone string
+
+var phonePunctuation = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// validDDDs are the area codes assigned by Anatel.
+var validDDDs = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true, "22": true, "24": true, "27": true, "28": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "37": true, "38": true,
+	"41": true, "42": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "53": true, "54": true, "55": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true, "66": true, "67": true, "68": true, "69": true,
+	"71": true, "73": true, "74": true, "75": true, "77": true, "79": true,
+	"81": true, "82": true, "83": true, "84": true, "85": true, "86": true, "87": true, "88": true, "89": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true, "98": true, "99": true,
+}
+
+// ParsePhone accepts a DDD followed by an 8 digit landline or a 9 digit
+// mobile number, with or without punctuation and the +55 country code:
+// "(11) 98765-4321", "11987654321" and "+55 11 3333-4444" are all valid.
+func ParsePhone(raw string) (Phone, error) {
+	digits := phonePunctuation.Replace(strings.TrimSpace(raw))
+	international := strings.HasPrefix(digits, "+")
+	digits = strings.TrimPrefix(digits, "+")
+	if !isDigits(digits) {
+		return "", errors.ErrPhoneInvalidNumber
+	}
+
+	if (len(digits) == 12 || len(digits) == 13) && strings.HasPrefix(digits, brazilCountryCode) {
+		digits = digits[len(brazilCountryCode):]
+	} else if international {
+		return "", errors.ErrPhoneInvalidNumber
+	}
+	if len(digits) != 10 && len(digits) != 11 {
+		return "", errors.ErrPhoneInvalidNumber
+	}
+
+	if !validDDDs[digits[:2]] {
+		return "", errors.ErrPhoneInvalidDDD
+	}
+
+	// Mobile numbers have 9 digits and start with 9; landlines have 8
+	// digits and start with 2 to 5.
+	number := digits[2:]
+	switch {
+	case len(number) == 9 && number[0] == '9':
+	case len(number) == 8 && number[0] >= '2' && number[0] <= '5':
+	default:
+		return "", errors.ErrPhoneInvalidNumber
+	}
+
+	return Phone("+" + brazilCountryCode + digits), nil
+}
+
+func (p Phone) String() string {
+	return string(p)
+}
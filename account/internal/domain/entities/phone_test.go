@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Phone
+		wantErr error
+	}{
+		{name: "mobile digits only", input: "11987654321", want: "+5511987654321"},
+		{name: "mobile formatted", input: "(11) 98765-4321", want: "+5511987654321"},
+		{name: "landline", input: "2133334444", want: "+552133334444"},
+		{name: "country code", input: "+55 21 3333-4444", want: "+552133334444"},
+		{name: "country code without plus", input: "5511987654321", want: "+5511987654321"},
+		{name: "already E.164", input: "+5511987654321", want: "+5511987654321"},
+		{name: "DDD 55", input: "55987654321", want: "+5555987654321"},
+		{name: "letters", input: "11 9876-ABCD", wantErr: domainErrors.ErrPhoneInvalidNumber},
+		{name: "too short", input: "119876543", wantErr: domainErrors.ErrPhoneInvalidNumber},
+		{name: "too long", input: "119876543210", wantErr: domainErrors.ErrPhoneInvalidNumber},
+		{name: "foreign country code", input: "+1 415 555 2671", wantErr: domainErrors.ErrPhoneInvalidNumber},
+		{name: "unassigned DDD", input: "20987654321", wantErr: domainErrors.ErrPhoneInvalidDDD},
+		{name: "DDD starting with zero", input: "01987654321", wantErr: domainErrors.ErrPhoneInvalidDDD},
+		{name: "mobile not starting with 9", input: "11887654321", wantErr: domainErrors.ErrPhoneInvalidNumber},
+		{name: "landline starting with 9", input: "1193334444", wantErr: domainErrors.ErrPhoneInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePhone(tt.input)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got != tt.want {
+				t.Errorf("ParsePhone(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
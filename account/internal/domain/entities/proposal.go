@@ -1,33 +1,102 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
-	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
 	"github.com/google/uuid"
 )
 
 type ProposalStatus string
 
+// An approved proposal waits in offer_pending until the customer accepts or
+// declines the offer, or the offer expires. A borderline analysis waits in
+// under_review for an operator decision. The customer may cancel the
+// proposal at any point before it is finalized. A proposal stuck in pending
+// or analyzing past its SLA ends as expired.
 const (
-	StatusPending   ProposalStatus = "pending"
-	StatusAnalyzing ProposalStatus = "analyzing"
-	StatusApproved  ProposalStatus = "approved"
-	StatusRejected  ProposalStatus = "rejected"
+	StatusPending      ProposalStatus = "pending"
+	StatusAnalyzing    ProposalStatus = "analyzing"
+	StatusUnderReview  ProposalStatus = "under_review"
+	StatusOfferPending ProposalStatus = "offer_pending"
+	StatusAccepted     ProposalStatus = "accepted"
+	StatusDeclined     ProposalStatus = "declined"
+	StatusOfferExpired ProposalStatus = "offer_expired"
+	StatusRejected     ProposalStatus = "rejected"
+	StatusCancelled    ProposalStatus = "cancelled"
+	StatusExpired      ProposalStatus = "expired"
 )
 
+func (s ProposalStatus) IsKnown() bool {
+	switch s {
+	case StatusPending, StatusAnalyzing, StatusUnderReview, StatusOfferPending,
+		StatusAccepted, StatusDeclined, StatusOfferExpired, StatusRejected, StatusCancelled, StatusExpired:
+		return true
+	}
+	return false
+}
+
 type Proposal struct {
 	ID        uuid.UUID
 	FullName  string
 	CPF       string
-	Salary    float64
+	Salary    money.Money
 	BirthDate time.Time
 	Email     string
 	Phone     string
 	Address   Address
 	Status    ProposalStatus
+	Rejection *RejectionReason
+	Review    *ReviewReason
+	Offer     *CreditOffer
+
+	// CancellationReason is the customer's reason, set once cancelled.
+	CancellationReason string
+
+	// AnalysisRetries counts how many times the analysis was requested again
+	// because risk-analysis did not answer in time.
+	AnalysisRetries int
+
+	// Version is the optimistic lock of the stored row. It starts at 1 and
+	// is incremented by every update.
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// pendingEvents are raised by status transitions, drained by PullEvents.
+	pendingEvents []domain.DomainEvent
+}
+
+// RejectionReason explains why risk-analysis rejected a proposal.
+type RejectionReason struct {
+	Code    string
+	Message string
+}
+
+// ReviewReason explains why risk-analysis sent a proposal to manual review.
+type ReviewReason struct {
+	Code    string
+	Message string
+}
+
+// CreditOffer is the limit and risk classification computed by
+// risk-analysis for an approved proposal, along with the card terms the
+// customer has to accept before the offer expires.
+type CreditOffer struct {
+	CreditLimit  money.Money
+	RiskTier     string
+	RiskScore    int
+	AnnualFee    money.Money
+	TermsVersion string
+	ExpiresAt    time.Time
+}
+
+// IsExpired reports whether the offer can no longer be accepted. Offers
+// without an expiry never expire.
+func (o *CreditOffer) IsExpired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
 }
 
 type Address struct {
@@ -37,73 +106,317 @@ type Address struct {
 	ZipCode string
 }
 
+// Field paths reported in ValidationErrors by NewProposal.
+const (
+	FieldFullName       = "full_name"
+	FieldCPF            = "cpf"
+	FieldSalary         = "salary"
+	FieldEmail          = "email"
+	FieldPhone          = "phone"
+	FieldBirthDate      = "birth_date"
+	FieldAddressState   = "address.state"
+	FieldAddressZipCode = "address.zip_code"
+)
+
+// normalize validates the state and zip code and stores them in their
+// canonical form ("SP", "01234567").
+func (a *Address) normalize(violations *domain.ValidationErrors) {
+	state, err := ParseUF(a.State)
+	violations.Add(FieldAddressState, err)
+	zipCode, err := ParseCEP(a.ZipCode)
+	violations.Add(FieldAddressZipCode, err)
+	a.State = state.String()
+	a.ZipCode = zipCode.String()
+}
+
+// NewProposal validates every field before failing, returning all violations
+// together as domain.ValidationErrors. The applicant's age must fit policy.
 func NewProposal(
 	fullName string,
 	cpf string,
-	salary float64,
+	salary money.Money,
 	email string,
 	phone string,
 	birthDate time.Time,
 	address Address,
+	policy AgePolicy,
 ) (*Proposal, error) {
+	var violations domain.ValidationErrors
+
 	if fullName == "" {
-		return nil, errors.ErrFullNameRequired
-	}
-	if cpf == "" {
-		return nil, errors.ErrCPFRequired
+		violations.Add(FieldFullName, domain.ErrFullNameRequired)
 	}
+	document, err := ParseCPF(cpf)
+	violations.Add(FieldCPF, err)
 	if salary <= 0 {
-		return nil, errors.ErrSalaryRequired
+		violations.Add(FieldSalary, domain.ErrSalaryRequired)
 	}
-	if email == "" {
-		return nil, errors.ErrEmailRequired
+	mailbox, err := ParseEmail(email)
+	violations.Add(FieldEmail, err)
+	if phone != "" {
+		number, err := ParsePhone(phone)
+		violations.Add(FieldPhone, err)
+		phone = number.String()
 	}
 	if birthDate.IsZero() {
-		return nil, errors.ErrBirthDateRequired
+		violations.Add(FieldBirthDate, domain.ErrBirthDateRequired)
+	} else {
+		violations.Add(FieldBirthDate, policy.Check(birthDate, time.Now()))
+	}
+	address.normalize(&violations)
+
+	if err := violations.Err(); err != nil {
+		return nil, err
 	}
 
 	return &Proposal{
 		ID:        uuid.New(),
 		FullName:  fullName,
-		CPF:       cpf,
+		CPF:       document.String(),
 		Salary:    salary,
-		Email:     email,
+		Email:     mailbox.String(),
 		Phone:     phone,
 		BirthDate: birthDate,
 		Address:   address,
 		Status:    StatusPending,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
 }
 
 func (p *Proposal) Approve() error {
+	return p.ApproveWithOffer(CreditOffer{})
+}
+
+// ApproveWithOffer approves the proposal keeping the offer computed by
+// risk-analysis, and waits for the customer to accept it. An empty offer
+// behaves like Approve.
+func (p *Proposal) ApproveWithOffer(offer CreditOffer) error {
 	if p.Status != StatusAnalyzing {
-		return errors.ErrOnlyAnalyzingCanBeApproved
+		return domain.ErrOnlyAnalyzingCanBeApproved
+	}
+	if offer != (CreditOffer{}) {
+		p.Offer = &offer
+	}
+	p.changeStatus(StatusOfferPending)
+	return nil
+}
+
+// SendToReview parks a borderline proposal until an operator decides it.
+// The offer computed by risk-analysis is kept for the approval.
+func (p *Proposal) SendToReview(reason ReviewReason, offer CreditOffer) error {
+	if p.Status != StatusAnalyzing {
+		return domain.ErrOnlyAnalyzingCanBeReviewed
+	}
+	p.Review = &reason
+	if offer != (CreditOffer{}) {
+		p.Offer = &offer
+	}
+	p.changeStatus(StatusUnderReview)
+	return nil
+}
+
+// ApproveReview presents the offer to the customer after an operator
+// approved the proposal.
+func (p *Proposal) ApproveReview(offer CreditOffer) error {
+	if p.Status != StatusUnderReview {
+		return domain.ErrOnlyUnderReviewCanBeDecided
+	}
+	if offer != (CreditOffer{}) {
+		p.Offer = &offer
+	}
+	p.changeStatus(StatusOfferPending)
+	return nil
+}
+
+// RejectReview rejects the proposal after an operator reviewed it.
+func (p *Proposal) RejectReview(reason RejectionReason) error {
+	if p.Status != StatusUnderReview {
+		return domain.ErrOnlyUnderReviewCanBeDecided
+	}
+	p.Rejection = &reason
+	p.Offer = nil
+	p.changeStatus(StatusRejected)
+	return nil
+}
+
+// AcceptOffer accepts the pending offer. The terms version must match the
+// one presented with the offer.
+func (p *Proposal) AcceptOffer(termsVersion string) error {
+	if termsVersion == "" {
+		return domain.ErrTermsVersionRequired
+	}
+	if err := p.checkOfferOpen(); err != nil {
+		return err
+	}
+	if p.Offer != nil && p.Offer.TermsVersion != termsVersion {
+		return domain.ErrTermsVersionMismatch
+	}
+	p.changeStatus(StatusAccepted)
+	return nil
+}
+
+func (p *Proposal) DeclineOffer() error {
+	if err := p.checkOfferOpen(); err != nil {
+		return err
+	}
+	p.changeStatus(StatusDeclined)
+	return nil
+}
+
+// ExpireOffer closes a pending offer whose deadline has passed.
+func (p *Proposal) ExpireOffer() error {
+	if p.Status != StatusOfferPending {
+		return domain.ErrOnlyOfferPendingCanBeDecided
+	}
+	if p.Offer == nil || !p.Offer.IsExpired(time.Now()) {
+		return domain.ErrOfferNotExpired
+	}
+	p.changeStatus(StatusOfferExpired)
+	return nil
+}
+
+func (p *Proposal) checkOfferOpen() error {
+	if p.Status != StatusOfferPending {
+		return domain.ErrOnlyOfferPendingCanBeDecided
+	}
+	if p.Offer != nil && p.Offer.IsExpired(time.Now()) {
+		return domain.ErrOfferExpired
 	}
-	p.Status = StatusApproved
-	p.UpdatedAt = time.Now()
 	return nil
 }
 
 func (p *Proposal) StartAnalysis() error {
 	if p.Status != StatusPending {
-		return errors.ErrOnlyPendingCanStartAnalysis
+		return domain.ErrOnlyPendingCanStartAnalysis
 	}
-	p.Status = StatusAnalyzing
-	p.UpdatedAt = time.Now()
+	p.changeStatus(StatusAnalyzing)
 	return nil
 }
 
 func (p *Proposal) Reject() error {
+	return p.RejectWithReason(RejectionReason{})
+}
+
+// RejectWithReason rejects the proposal keeping the reason reported by
+// risk-analysis. An empty reason behaves like Reject.
+func (p *Proposal) RejectWithReason(reason RejectionReason) error {
+	if p.Status != StatusPending && p.Status != StatusAnalyzing {
+		return domain.ErrOnlyPendingOrAnalyzingCanReject
+	}
+	if reason.Code != "" || reason.Message != "" {
+		p.Rejection = &reason
+	}
+	p.changeStatus(StatusRejected)
+	return nil
+}
+
+// Cancel withdraws a proposal that is not finalized yet, at the customer's
+// request.
+func (p *Proposal) Cancel(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return domain.ErrCancellationReasonRequired
+	}
+	if p.IsFinalized() {
+		return domain.ErrOnlyOpenProposalsCanBeCancelled
+	}
+	p.CancellationReason = strings.TrimSpace(reason)
+	p.changeStatus(StatusCancelled)
+	return nil
+}
+
+// RetryAnalysis records that the analysis was requested again for a
+// proposal stuck in pending or analyzing. The status is kept and the SLA
+// restarts.
+func (p *Proposal) RetryAnalysis() error {
+	if p.Status != StatusPending && p.Status != StatusAnalyzing {
+		return domain.ErrOnlyPendingOrAnalyzingCanBeRetried
+	}
+	p.AnalysisRetries++
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Expire closes a proposal stuck in pending or analyzing past its SLA.
+func (p *Proposal) Expire() error {
 	if p.Status != StatusPending && p.Status != StatusAnalyzing {
-		return errors.ErrOnlyPendingOrAnalyzingCanReject
+		return domain.ErrOnlyPendingOrAnalyzingCanExpire
+	}
+	p.changeStatus(StatusExpired)
+	return nil
+}
+
+// AttachDocument accepts a document while the proposal has not been sent
+// to risk-analysis yet.
+func (p *Proposal) AttachDocument() error {
+	if p.Status != StatusPending {
+		return domain.ErrOnlyPendingCanReceiveDocuments
 	}
-	p.Status = StatusRejected
 	p.UpdatedAt = time.Now()
 	return nil
 }
 
+// PullEvents returns the events raised since the last call and clears them.
+func (p *Proposal) PullEvents() []domain.DomainEvent {
+	pulled := p.pendingEvents
+	p.pendingEvents = nil
+	return pulled
+}
+
+func (p *Proposal) changeStatus(next ProposalStatus) {
+	previous := p.Status
+	p.Status = next
+	p.UpdatedAt = time.Now()
+
+	p.record(domain.EventProposalStatusChanged, previous)
+	switch next {
+	case StatusUnderReview:
+		p.record(domain.EventProposalUnderReview, previous)
+	case StatusOfferPending:
+		p.record(domain.EventProposalApproved, previous)
+	case StatusRejected:
+		p.record(domain.EventProposalRejected, previous)
+	case StatusAccepted:
+		p.record(domain.EventProposalOfferAccepted, previous)
+	case StatusDeclined:
+		p.record(domain.EventProposalOfferDeclined, previous)
+	case StatusOfferExpired:
+		p.record(domain.EventProposalOfferExpired, previous)
+	case StatusCancelled:
+		p.record(domain.EventProposalCancelled, previous)
+	case StatusExpired:
+		p.record(domain.EventProposalExpired, previous)
+	}
+}
+
+func (p *Proposal) record(eventType string, previous ProposalStatus) {
+	event := &domain.ProposalLifecycleEvent{
+		EventType:      eventType,
+		ProposalID:     p.ID,
+		PreviousStatus: string(previous),
+		Status:         string(p.Status),
+		OccurredAt:     p.UpdatedAt,
+	}
+	if p.Rejection != nil {
+		event.ReasonCode = p.Rejection.Code
+		event.ReasonMessage = p.Rejection.Message
+	}
+	if p.Status == StatusCancelled {
+		event.ReasonMessage = p.CancellationReason
+	}
+	if p.Status == StatusUnderReview && p.Review != nil {
+		event.ReasonCode = p.Review.Code
+		event.ReasonMessage = p.Review.Message
+	}
+	if p.Offer != nil {
+		event.CreditLimit = p.Offer.CreditLimit
+		event.CreditLimitCents = p.Offer.CreditLimit.Cents()
+		event.RiskTier = p.Offer.RiskTier
+	}
+	p.pendingEvents = append(p.pendingEvents, event)
+}
+
 func (p *Proposal) IsPending() bool {
 	return p.Status == StatusPending
 }
@@ -112,21 +425,47 @@ func (p *Proposal) IsAnalyzing() bool {
 	return p.Status == StatusAnalyzing
 }
 
+func (p *Proposal) IsUnderReview() bool {
+	return p.Status == StatusUnderReview
+}
+
+func (p *Proposal) IsOfferPending() bool {
+	return p.Status == StatusOfferPending
+}
+
+// IsFinalized reports whether the proposal reached a terminal status.
 func (p *Proposal) IsFinalized() bool {
-	return p.Status == StatusApproved || p.Status == StatusRejected
+	switch p.Status {
+	case StatusAccepted, StatusDeclined, StatusOfferExpired, StatusRejected, StatusCancelled, StatusExpired:
+		return true
+	}
+	return false
+}
+
+func (p *Proposal) IsCancelled() bool {
+	return p.Status == StatusCancelled
+}
+
+func (p *Proposal) IsExpired() bool {
+	return p.Status == StatusExpired
 }
 
 func (p *Proposal) IsValid() bool {
 	return p.ID != uuid.Nil &&
 		p.FullName != "" &&
-		p.CPF != "" &&
+		isValid(ParseCPF(p.CPF)) &&
 		p.Salary != 0 &&
-		p.Email != "" &&
-		p.Phone != "" &&
+		isValid(ParseEmail(p.Email)) &&
+		isValid(ParsePhone(p.Phone)) &&
 		!p.BirthDate.IsZero() &&
 		p.Address.Street != "" &&
 		p.Address.City != "" &&
-		p.Address.State != "" &&
-		p.Address.ZipCode != "" &&
+		isValid(ParseUF(p.Address.State)) &&
+		isValid(ParseCEP(p.Address.ZipCode)) &&
 		p.Status != ""
 }
+
+// isValid adapts a value object parser to a boolean check.
+func isValid[T any](_ T, err error) bool {
+	return err == nil
+}
@@ -2,29 +2,32 @@ package entities
 
 import (
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
 	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
 	"github.com/google/uuid"
 )
 
 type ProposalBuilder struct {
 	fullName  string
 	cpf       string
-	salary    float64
+	salary    money.Money
 	email     string
 	phone     string
 	birthDate time.Time
 	address   Address
 	status    ProposalStatus
+	agePolicy AgePolicy
 }
 
 func NewProposalBuilder() *ProposalBuilder {
 	return &ProposalBuilder{
 		fullName:  "John Doe",
-		cpf:       "12345678901",
-		salary:    5000.00,
+		cpf:       "12345678909",
+		salary:    money.MustParse("5000.00"),
 		email:     "john@example.com",
 		phone:     "11999999999",
 		birthDate: time.Date(1990, 1, 15, 0, 0, 0, 0, time.UTC),
@@ -48,7 +51,7 @@ func (b *ProposalBuilder) WithCPF(cpf string) *ProposalBuilder {
 	return b
 }
 
-func (b *ProposalBuilder) WithSalary(salary float64) *ProposalBuilder {
+func (b *ProposalBuilder) WithSalary(salary money.Money) *ProposalBuilder {
 	b.salary = salary
 	return b
 }
@@ -68,6 +71,11 @@ func (b *ProposalBuilder) WithBirthDate(birthDate time.Time) *ProposalBuilder {
 	return b
 }
 
+func (b *ProposalBuilder) WithAgePolicy(policy AgePolicy) *ProposalBuilder {
+	b.agePolicy = policy
+	return b
+}
+
 func (b *ProposalBuilder) WithAddress(address Address) *ProposalBuilder {
 	b.address = address
 	return b
@@ -95,7 +103,7 @@ func (b *ProposalBuilder) Build() *Proposal {
 }
 
 func (b *ProposalBuilder) BuildWithValidation() (*Proposal, error) {
-	return NewProposal(b.fullName, b.cpf, b.salary, b.email, b.phone, b.birthDate, b.address)
+	return NewProposal(b.fullName, b.cpf, b.salary, b.email, b.phone, b.birthDate, b.address, b.agePolicy)
 }
 
 func assertNoError(t *testing.T, err error) {
@@ -133,6 +141,19 @@ func assertBool(t *testing.T, got bool, message string) {
 	}
 }
 
+func assertEventTypes(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected events %v, got %v", want, got)
+			return
+		}
+	}
+}
+
 func TestNewProposal(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -157,6 +178,12 @@ func TestNewProposal(t *testing.T) {
 			wantErr:     true,
 			expectedErr: domainErrors.ErrCPFRequired,
 		},
+		{
+			name:        "should return error when CPF check digits are invalid",
+			builder:     NewProposalBuilder().WithCPF("12345678901"),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrCPFInvalidCheckDigits,
+		},
 		{
 			name:        "should return error when salary is zero",
 			builder:     NewProposalBuilder().WithSalary(0),
@@ -169,17 +196,53 @@ func TestNewProposal(t *testing.T) {
 			wantErr:     true,
 			expectedErr: domainErrors.ErrEmailRequired,
 		},
+		{
+			name:        "should return error when email is invalid",
+			builder:     NewProposalBuilder().WithEmail("john.example.com"),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrEmailInvalid,
+		},
 		{
 			name:    "should allow empty phone number",
 			builder: NewProposalBuilder().WithPhone(""),
 			wantErr: false,
 		},
+		{
+			name:        "should return error when phone DDD is invalid",
+			builder:     NewProposalBuilder().WithPhone("20987654321"),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrPhoneInvalidDDD,
+		},
+		{
+			name:        "should return error when state is not a UF",
+			builder:     NewProposalBuilder().WithAddress(Address{Street: "123 Main", City: "SP", State: "XX", ZipCode: "01234-567"}),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrStateInvalid,
+		},
+		{
+			name:        "should return error when zip code is invalid",
+			builder:     NewProposalBuilder().WithAddress(Address{Street: "123 Main", City: "SP", State: "SP", ZipCode: "1234"}),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrZipCodeInvalid,
+		},
 		{
 			name:        "should return error when birth date is zero",
 			builder:     NewProposalBuilder().WithBirthDate(time.Time{}),
 			wantErr:     true,
 			expectedErr: domainErrors.ErrBirthDateRequired,
 		},
+		{
+			name:        "should return error when applicant is underage",
+			builder:     NewProposalBuilder().WithBirthDate(time.Now().AddDate(-16, 0, 0)),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrApplicantUnderage,
+		},
+		{
+			name:        "should return error when applicant is above the policy max age",
+			builder:     NewProposalBuilder().WithAgePolicy(AgePolicy{MaxAge: 30}),
+			wantErr:     true,
+			expectedErr: domainErrors.ErrApplicantAboveMaxAge,
+		},
 	}
 
 	for _, tt := range tests {
@@ -197,8 +260,56 @@ func TestNewProposal(t *testing.T) {
 				t.Error("expected non-nil UUID")
 			}
 			assertStatus(t, proposal.Status, StatusPending)
+			if proposal.Version != 1 {
+				t.Errorf("expected version 1, got %d", proposal.Version)
+			}
+			if !isDigits(proposal.CPF) {
+				t.Errorf("expected normalized CPF, got %q", proposal.CPF)
+			}
 		})
 	}
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		_, err := NewProposalBuilder().
+			WithFullName("").
+			WithCPF("12345678901").
+			WithEmail("john@").
+			WithAddress(Address{Street: "123 Main", City: "SP", State: "XX", ZipCode: "01234-567"}).
+			BuildWithValidation()
+
+		var violations domainErrors.ValidationErrors
+		if !errors.As(err, &violations) {
+			t.Fatalf("expected ValidationErrors, got %T", err)
+		}
+		var fields []string
+		for _, violation := range violations {
+			fields = append(fields, violation.Field)
+		}
+		want := []string{FieldFullName, FieldCPF, FieldEmail, FieldAddressState}
+		if !slices.Equal(fields, want) {
+			t.Errorf("expected fields %v, got %v", want, fields)
+		}
+		assertErrorIs(t, err, domainErrors.ErrStateInvalid)
+	})
+
+	t.Run("should normalize contact and address fields", func(t *testing.T) {
+		proposal, err := NewProposalBuilder().
+			WithEmail("John@Example.COM").
+			WithPhone("(11) 98765-4321").
+			WithAddress(Address{Street: "123 Main", City: "São Paulo", State: "sp", ZipCode: "01234-567"}).
+			BuildWithValidation()
+
+		assertNoError(t, err)
+		if proposal.Email != "John@example.com" {
+			t.Errorf("expected email John@example.com, got %q", proposal.Email)
+		}
+		if proposal.Phone != "+5511987654321" {
+			t.Errorf("expected phone +5511987654321, got %q", proposal.Phone)
+		}
+		if proposal.Address.State != "SP" || proposal.Address.ZipCode != "01234567" {
+			t.Errorf("expected state SP and zip 01234567, got %q and %q", proposal.Address.State, proposal.Address.ZipCode)
+		}
+	})
 }
 
 func TestProposalStateTransitions(t *testing.T) {
@@ -215,17 +326,17 @@ func TestProposalStateTransitions(t *testing.T) {
 		assertErrorIs(t, err, domainErrors.ErrOnlyPendingCanStartAnalysis)
 	})
 
-	t.Run("should return error when starting analysis from approved status", func(t *testing.T) {
-		p := NewProposalBuilder().WithStatus(StatusApproved).Build()
+	t.Run("should return error when starting analysis from offer pending status", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
 		err := p.StartAnalysis()
 		assertError(t, err)
 		assertErrorIs(t, err, domainErrors.ErrOnlyPendingCanStartAnalysis)
 	})
 
-	t.Run("should transition from analyzing to approved", func(t *testing.T) {
+	t.Run("should transition from analyzing to offer pending on approval", func(t *testing.T) {
 		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
 		assertNoError(t, p.Approve())
-		assertStatus(t, p.Status, StatusApproved)
+		assertStatus(t, p.Status, StatusOfferPending)
 	})
 
 	t.Run("should return error when approving from pending status", func(t *testing.T) {
@@ -242,6 +353,23 @@ func TestProposalStateTransitions(t *testing.T) {
 		assertErrorIs(t, err, domainErrors.ErrOnlyAnalyzingCanBeApproved)
 	})
 
+	t.Run("should keep offer when approving with offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.ApproveWithOffer(CreditOffer{CreditLimit: money.MustParse("3000"), RiskTier: "HIGH", RiskScore: 400}))
+		assertStatus(t, p.Status, StatusOfferPending)
+		if p.Offer == nil || p.Offer.CreditLimit != money.MustParse("3000") || p.Offer.RiskTier != "HIGH" {
+			t.Errorf("expected offer 3000/HIGH, got %+v", p.Offer)
+		}
+	})
+
+	t.Run("should not set offer when approving without one", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.Approve())
+		if p.Offer != nil {
+			t.Errorf("expected no offer, got %+v", p.Offer)
+		}
+	})
+
 	t.Run("should transition from pending to rejected", func(t *testing.T) {
 		p := NewProposalBuilder().Build()
 		assertNoError(t, p.Reject())
@@ -254,8 +382,34 @@ func TestProposalStateTransitions(t *testing.T) {
 		assertStatus(t, p.Status, StatusRejected)
 	})
 
-	t.Run("should return error when rejecting from approved status", func(t *testing.T) {
-		p := NewProposalBuilder().WithStatus(StatusApproved).Build()
+	t.Run("should keep reason when rejecting with reason", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.RejectWithReason(RejectionReason{Code: "FRAUD_SUSPECTED", Message: "CPF failed fraud check"}))
+		assertStatus(t, p.Status, StatusRejected)
+		if p.Rejection == nil || p.Rejection.Code != "FRAUD_SUSPECTED" {
+			t.Errorf("expected rejection code FRAUD_SUSPECTED, got %+v", p.Rejection)
+		}
+	})
+
+	t.Run("should not set rejection when reason is empty", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertNoError(t, p.RejectWithReason(RejectionReason{}))
+		if p.Rejection != nil {
+			t.Errorf("expected no rejection reason, got %+v", p.Rejection)
+		}
+	})
+
+	t.Run("should not set rejection reason when rejecting from offer pending status", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
+		err := p.RejectWithReason(RejectionReason{Code: "FRAUD_SUSPECTED"})
+		assertErrorIs(t, err, domainErrors.ErrOnlyPendingOrAnalyzingCanReject)
+		if p.Rejection != nil {
+			t.Error("expected rejection reason to stay empty")
+		}
+	})
+
+	t.Run("should return error when rejecting from accepted status", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAccepted).Build()
 		err := p.Reject()
 		assertError(t, err)
 		assertErrorIs(t, err, domainErrors.ErrOnlyPendingOrAnalyzingCanReject)
@@ -269,6 +423,281 @@ func TestProposalStateTransitions(t *testing.T) {
 	})
 }
 
+func TestProposalOfferDecisions(t *testing.T) {
+	pendingOffer := func(expiresAt time.Time) *Proposal {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
+		p.Offer = &CreditOffer{CreditLimit: money.MustParse("3000"), TermsVersion: "v1", ExpiresAt: expiresAt}
+		return p
+	}
+
+	t.Run("should accept offer with matching terms version", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(time.Hour))
+		assertNoError(t, p.AcceptOffer("v1"))
+		assertStatus(t, p.Status, StatusAccepted)
+	})
+
+	t.Run("should require terms version to accept", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(time.Hour))
+		assertErrorIs(t, p.AcceptOffer(""), domainErrors.ErrTermsVersionRequired)
+		assertStatus(t, p.Status, StatusOfferPending)
+	})
+
+	t.Run("should return error when terms version does not match", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(time.Hour))
+		assertErrorIs(t, p.AcceptOffer("v0"), domainErrors.ErrTermsVersionMismatch)
+		assertStatus(t, p.Status, StatusOfferPending)
+	})
+
+	t.Run("should return error when accepting an expired offer", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(-time.Minute))
+		assertErrorIs(t, p.AcceptOffer("v1"), domainErrors.ErrOfferExpired)
+		assertStatus(t, p.Status, StatusOfferPending)
+	})
+
+	t.Run("should return error when accepting without a pending offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertErrorIs(t, p.AcceptOffer("v1"), domainErrors.ErrOnlyOfferPendingCanBeDecided)
+	})
+
+	t.Run("should decline offer", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(time.Hour))
+		assertNoError(t, p.DeclineOffer())
+		assertStatus(t, p.Status, StatusDeclined)
+	})
+
+	t.Run("should return error when declining an accepted offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAccepted).Build()
+		assertErrorIs(t, p.DeclineOffer(), domainErrors.ErrOnlyOfferPendingCanBeDecided)
+	})
+
+	t.Run("should expire offer past its deadline", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(-time.Minute))
+		assertNoError(t, p.ExpireOffer())
+		assertStatus(t, p.Status, StatusOfferExpired)
+	})
+
+	t.Run("should not expire offer before its deadline", func(t *testing.T) {
+		p := pendingOffer(time.Now().Add(time.Hour))
+		assertErrorIs(t, p.ExpireOffer(), domainErrors.ErrOfferNotExpired)
+		assertStatus(t, p.Status, StatusOfferPending)
+	})
+}
+
+func TestProposalCancel(t *testing.T) {
+	for _, status := range []ProposalStatus{StatusPending, StatusAnalyzing, StatusOfferPending} {
+		t.Run("should cancel "+string(status)+" proposal", func(t *testing.T) {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertNoError(t, p.Cancel(" changed my mind "))
+			assertStatus(t, p.Status, StatusCancelled)
+			if p.CancellationReason != "changed my mind" {
+				t.Errorf("expected trimmed reason, got %q", p.CancellationReason)
+			}
+			assertBool(t, p.IsFinalized(), "expected cancelled proposal to be finalized")
+		})
+	}
+
+	for _, status := range []ProposalStatus{StatusAccepted, StatusDeclined, StatusOfferExpired, StatusRejected, StatusCancelled} {
+		t.Run("should return error when cancelling "+string(status)+" proposal", func(t *testing.T) {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertErrorIs(t, p.Cancel("changed my mind"), domainErrors.ErrOnlyOpenProposalsCanBeCancelled)
+			assertStatus(t, p.Status, status)
+		})
+	}
+
+	t.Run("should require a reason", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertErrorIs(t, p.Cancel(""), domainErrors.ErrCancellationReasonRequired)
+		assertStatus(t, p.Status, StatusPending)
+	})
+
+	t.Run("should raise cancelled with the reason", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.Cancel("changed my mind"))
+
+		pulled := p.PullEvents()
+		if len(pulled) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(pulled))
+		}
+		cancelled, ok := pulled[1].(*domainErrors.ProposalLifecycleEvent)
+		if !ok || cancelled.EventType != domainErrors.EventProposalCancelled {
+			t.Fatalf("expected ProposalCancelled, got %+v", pulled[1])
+		}
+		if cancelled.ReasonMessage != "changed my mind" {
+			t.Errorf("expected reason in event, got %q", cancelled.ReasonMessage)
+		}
+	})
+}
+
+func TestProposalExpire(t *testing.T) {
+	for _, status := range []ProposalStatus{StatusPending, StatusAnalyzing} {
+		t.Run("should expire "+string(status)+" proposal", func(t *testing.T) {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertNoError(t, p.Expire())
+			assertStatus(t, p.Status, StatusExpired)
+			assertBool(t, p.IsFinalized(), "expected expired proposal to be finalized")
+
+			pulled := p.PullEvents()
+			if len(pulled) != 2 {
+				t.Fatalf("expected 2 events, got %d", len(pulled))
+			}
+			expired, ok := pulled[1].(*domainErrors.ProposalLifecycleEvent)
+			if !ok || expired.EventType != domainErrors.EventProposalExpired || expired.PreviousStatus != string(status) {
+				t.Errorf("expected ProposalExpired from %s, got %+v", status, pulled[1])
+			}
+		})
+	}
+
+	for _, status := range []ProposalStatus{StatusUnderReview, StatusOfferPending, StatusRejected, StatusExpired} {
+		t.Run("should return error when expiring "+string(status)+" proposal", func(t *testing.T) {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertErrorIs(t, p.Expire(), domainErrors.ErrOnlyPendingOrAnalyzingCanExpire)
+			assertStatus(t, p.Status, status)
+		})
+	}
+}
+
+func TestProposalRetryAnalysis(t *testing.T) {
+	t.Run("should count retries and keep the status", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.RetryAnalysis())
+		assertNoError(t, p.RetryAnalysis())
+		assertStatus(t, p.Status, StatusAnalyzing)
+		if p.AnalysisRetries != 2 {
+			t.Errorf("expected 2 retries, got %d", p.AnalysisRetries)
+		}
+		if len(p.PullEvents()) != 0 {
+			t.Error("expected no events for a retry")
+		}
+	})
+
+	t.Run("should return error once the proposal left analysis", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
+		assertErrorIs(t, p.RetryAnalysis(), domainErrors.ErrOnlyPendingOrAnalyzingCanBeRetried)
+	})
+}
+
+func TestProposalManualReview(t *testing.T) {
+	reason := ReviewReason{Code: "SALARY_BORDERLINE", Message: "salary is close to the minimum"}
+	offer := CreditOffer{CreditLimit: money.MustParse("1600"), RiskTier: "HIGH", RiskScore: 358}
+
+	t.Run("should send analyzing proposal to review keeping the offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.SendToReview(reason, offer))
+		assertStatus(t, p.Status, StatusUnderReview)
+		if p.Review == nil || *p.Review != reason || p.Offer == nil || *p.Offer != offer {
+			t.Errorf("expected review and offer kept, got %+v %+v", p.Review, p.Offer)
+		}
+		assertBool(t, !p.IsFinalized(), "expected proposal under review not to be finalized")
+	})
+
+	t.Run("should return error when sending pending proposal to review", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertErrorIs(t, p.SendToReview(reason, offer), domainErrors.ErrOnlyAnalyzingCanBeReviewed)
+		assertStatus(t, p.Status, StatusPending)
+	})
+
+	t.Run("should raise under review with the reason", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.SendToReview(reason, offer))
+
+		pulled := p.PullEvents()
+		if len(pulled) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(pulled))
+		}
+		review, ok := pulled[1].(*domainErrors.ProposalLifecycleEvent)
+		if !ok || review.EventType != domainErrors.EventProposalUnderReview || review.ReasonCode != reason.Code {
+			t.Errorf("expected ProposalUnderReview with reason, got %+v", pulled[1])
+		}
+	})
+
+	t.Run("should approve review presenting the offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusUnderReview).Build()
+		assertNoError(t, p.ApproveReview(offer))
+		assertStatus(t, p.Status, StatusOfferPending)
+	})
+
+	t.Run("should reject review dropping the offer", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusUnderReview).Build()
+		p.Offer = &offer
+		assertNoError(t, p.RejectReview(RejectionReason{Code: "MANUAL_REVIEW_REJECTED"}))
+		assertStatus(t, p.Status, StatusRejected)
+		if p.Offer != nil {
+			t.Errorf("expected offer dropped, got %+v", p.Offer)
+		}
+	})
+
+	for _, status := range []ProposalStatus{StatusAnalyzing, StatusOfferPending, StatusRejected} {
+		t.Run("should return error when deciding "+string(status)+" proposal", func(t *testing.T) {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertErrorIs(t, p.ApproveReview(offer), domainErrors.ErrOnlyUnderReviewCanBeDecided)
+			assertErrorIs(t, p.RejectReview(RejectionReason{}), domainErrors.ErrOnlyUnderReviewCanBeDecided)
+			assertStatus(t, p.Status, status)
+		})
+	}
+}
+
+func TestProposalDomainEvents(t *testing.T) {
+	eventTypes := func(p *Proposal) []string {
+		var types []string
+		for _, event := range p.PullEvents() {
+			types = append(types, event.EventName())
+		}
+		return types
+	}
+
+	t.Run("should raise status changed when analysis starts", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertNoError(t, p.StartAnalysis())
+		assertEventTypes(t, eventTypes(p), domainErrors.EventProposalStatusChanged)
+	})
+
+	t.Run("should raise status changed and approved on approval", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.Approve())
+		assertEventTypes(t, eventTypes(p), domainErrors.EventProposalStatusChanged, domainErrors.EventProposalApproved)
+	})
+
+	t.Run("should raise rejected with reason on rejection", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAnalyzing).Build()
+		assertNoError(t, p.RejectWithReason(RejectionReason{Code: "FRAUD_SUSPECTED", Message: "CPF failed fraud check"}))
+
+		pulled := p.PullEvents()
+		if len(pulled) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(pulled))
+		}
+		rejected, ok := pulled[1].(*domainErrors.ProposalLifecycleEvent)
+		if !ok || rejected.EventType != domainErrors.EventProposalRejected {
+			t.Fatalf("expected ProposalRejected, got %+v", pulled[1])
+		}
+		if rejected.PreviousStatus != string(StatusAnalyzing) || rejected.ReasonCode != "FRAUD_SUSPECTED" {
+			t.Errorf("unexpected rejected event %+v", rejected)
+		}
+	})
+
+	t.Run("should raise offer accepted on acceptance", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
+		assertNoError(t, p.AcceptOffer("v1"))
+		assertEventTypes(t, eventTypes(p), domainErrors.EventProposalStatusChanged, domainErrors.EventProposalOfferAccepted)
+	})
+
+	t.Run("should clear events once pulled", func(t *testing.T) {
+		p := NewProposalBuilder().Build()
+		assertNoError(t, p.StartAnalysis())
+		p.PullEvents()
+		if len(p.PullEvents()) != 0 {
+			t.Error("expected no events after pulling")
+		}
+	})
+
+	t.Run("should not raise events on invalid transition", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusAccepted).Build()
+		assertError(t, p.Reject())
+		if len(p.PullEvents()) != 0 {
+			t.Error("expected no events")
+		}
+	})
+}
+
 func TestProposalStatusQueries(t *testing.T) {
 	t.Run("should return true for IsPending when status is pending", func(t *testing.T) {
 		p := NewProposalBuilder().Build()
@@ -282,9 +711,17 @@ func TestProposalStatusQueries(t *testing.T) {
 		assertBool(t, !p.IsPending() && !p.IsFinalized(), "expected IsPending and IsFinalized to return false")
 	})
 
-	t.Run("should return true for IsFinalized when status is approved", func(t *testing.T) {
-		p := NewProposalBuilder().WithStatus(StatusApproved).Build()
-		assertBool(t, p.IsFinalized(), "expected IsFinalized to return true for approved status")
+	t.Run("should return false for IsFinalized while the offer is pending", func(t *testing.T) {
+		p := NewProposalBuilder().WithStatus(StatusOfferPending).Build()
+		assertBool(t, p.IsOfferPending(), "expected IsOfferPending to return true")
+		assertBool(t, !p.IsFinalized(), "expected IsFinalized to return false for offer_pending status")
+	})
+
+	t.Run("should return true for IsFinalized when offer is answered or expired", func(t *testing.T) {
+		for _, status := range []ProposalStatus{StatusAccepted, StatusDeclined, StatusOfferExpired} {
+			p := NewProposalBuilder().WithStatus(status).Build()
+			assertBool(t, p.IsFinalized(), "expected IsFinalized to return true for "+string(status))
+		}
 	})
 
 	t.Run("should return true for IsFinalized when status is rejected", func(t *testing.T) {
@@ -320,6 +757,9 @@ func TestProposalIsValid(t *testing.T) {
 			{"empty address city", NewProposalBuilder().WithAddress(Address{Street: "123 Main", State: "SP", ZipCode: "01234-567"})},
 			{"empty address state", NewProposalBuilder().WithAddress(Address{Street: "123 Main", City: "SP", ZipCode: "01234-567"})},
 			{"empty address zip", NewProposalBuilder().WithAddress(Address{Street: "123 Main", City: "SP", State: "SP"})},
+			{"invalid email", NewProposalBuilder().WithEmail("john@")},
+			{"invalid phone", NewProposalBuilder().WithPhone("123")},
+			{"invalid address state", NewProposalBuilder().WithAddress(Address{Street: "123 Main", City: "SP", State: "XX", ZipCode: "01234-567"})},
 			{"empty status", NewProposalBuilder().WithStatus("")},
 		}
 
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+// ReapplicationPolicy decides whether a CPF may apply again. A CPF can
+// have a single open proposal, and after a rejection it waits Cooldown
+// before applying again.
+type ReapplicationPolicy struct {
+	Cooldown time.Duration
+}
+
+// Check validates a new proposal against the latest one for the same CPF,
+// which is nil for a first application.
+func (p ReapplicationPolicy) Check(latest *Proposal, now time.Time) error {
+	if latest == nil {
+		return nil
+	}
+	if !latest.IsFinalized() {
+		return errors.ErrProposalAlreadyOpen
+	}
+	if latest.Status == StatusRejected && now.Before(p.AvailableAt(latest)) {
+		return errors.ErrReapplicationCooldown
+	}
+	return nil
+}
+
+// AvailableAt is when the CPF of a rejected proposal may apply again.
+func (p ReapplicationPolicy) AvailableAt(rejected *Proposal) time.Time {
+	return rejected.UpdatedAt.Add(p.Cooldown)
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestReapplicationPolicy_Check(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := ReapplicationPolicy{Cooldown: 30 * 24 * time.Hour}
+	latest := func(status ProposalStatus, updatedAt time.Time) *Proposal {
+		proposal := NewProposalBuilder().WithStatus(status).Build()
+		proposal.UpdatedAt = updatedAt
+		return proposal
+	}
+
+	tests := []struct {
+		name        string
+		latest      *Proposal
+		expectedErr error
+	}{
+		{name: "first application", latest: nil},
+		{name: "pending proposal is open", latest: latest(StatusPending, now.AddDate(-1, 0, 0)), expectedErr: domainErrors.ErrProposalAlreadyOpen},
+		{name: "analyzing proposal is open", latest: latest(StatusAnalyzing, now), expectedErr: domainErrors.ErrProposalAlreadyOpen},
+		{name: "pending offer is open", latest: latest(StatusOfferPending, now), expectedErr: domainErrors.ErrProposalAlreadyOpen},
+		{name: "rejected within cooldown", latest: latest(StatusRejected, now.Add(-29*24*time.Hour)), expectedErr: domainErrors.ErrReapplicationCooldown},
+		{name: "rejected exactly at cooldown end", latest: latest(StatusRejected, now.Add(-30*24*time.Hour))},
+		{name: "rejected after cooldown", latest: latest(StatusRejected, now.AddDate(0, -2, 0))},
+		{name: "declined offer has no cooldown", latest: latest(StatusDeclined, now)},
+		{name: "expired offer has no cooldown", latest: latest(StatusOfferExpired, now)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.latest, now)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
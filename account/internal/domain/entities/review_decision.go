@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ReviewOutcome string
+
+const (
+	ReviewOutcomeApproved ReviewOutcome = "approved"
+	ReviewOutcomeRejected ReviewOutcome = "rejected"
+)
+
+// ReviewDecision records which operator decided a proposal under manual
+// review, and why.
+type ReviewDecision struct {
+	ID         uuid.UUID
+	ProposalID uuid.UUID
+	Outcome    ReviewOutcome
+	Operator   string
+	Notes      string
+	DecidedAt  time.Time
+}
+
+// NewReviewDecision requires both the operator identity and the notes.
+func NewReviewDecision(
+	proposalID uuid.UUID,
+	outcome ReviewOutcome,
+	operator string,
+	notes string,
+) (*ReviewDecision, error) {
+	operator, notes = strings.TrimSpace(operator), strings.TrimSpace(notes)
+	if operator == "" {
+		return nil, errors.ErrReviewOperatorRequired
+	}
+	if notes == "" {
+		return nil, errors.ErrReviewNotesRequired
+	}
+
+	return &ReviewDecision{
+		ID:         uuid.New(),
+		ProposalID: proposalID,
+		Outcome:    outcome,
+		Operator:   operator,
+		Notes:      notes,
+		DecidedAt:  time.Now(),
+	}, nil
+}
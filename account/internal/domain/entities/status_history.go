@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusHistoryEntry records a single proposal status transition and the
+// event that caused it.
+type StatusHistoryEntry struct {
+	ID             uuid.UUID
+	ProposalID     uuid.UUID
+	PreviousStatus ProposalStatus
+	NewStatus      ProposalStatus
+	EventType      string
+	MessageID      string
+	OccurredAt     time.Time
+}
+
+func NewStatusHistoryEntry(
+	proposalID uuid.UUID,
+	previous ProposalStatus,
+	current ProposalStatus,
+	eventType string,
+	messageID string,
+) *StatusHistoryEntry {
+	return &StatusHistoryEntry{
+		ID:             uuid.New(),
+		ProposalID:     proposalID,
+		PreviousStatus: previous,
+		NewStatus:      current,
+		EventType:      eventType,
+		MessageID:      messageID,
+		OccurredAt:     time.Now(),
+	}
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"strings"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+// UF is the two letter code of a Brazilian state or the Federal District.
+type UF string
+
+var validUFs = map[UF]bool{
+	"AC": true, "AL": true, "AP": true, "AM": true, "BA": true, "CE": true, "DF": true,
+	"ES": true, "GO": true, "MA": true, "MT": true, "MS": true, "MG": true, "PA": true,
+	"PB": true, "PR": true, "PE": true, "PI": true, "RJ": true, "RN": true, "RS": true,
+	"RO": true, "RR": true, "SC": true, "SP": true, "SE": true, "TO": true,
+}
+
+// ParseUF accepts one of the 27 UF codes in any case ("sp", "SP").
+func ParseUF(raw string) (UF, error) {
+	value := UF(strings.ToUpper(strings.TrimSpace(raw)))
+	if value == "" {
+		return "", errors.ErrStateRequired
+	}
+	if !validUFs[value] {
+		return "", errors.ErrStateInvalid
+	}
+	return value, nil
+}
+
+func (u UF) String() string {
+	return string(u)
+}
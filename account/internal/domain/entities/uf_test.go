@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestParseUF(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    UF
+		wantErr error
+	}{
+		{name: "upper case", input: "SP", want: "SP"},
+		{name: "lower case", input: "rj", want: "RJ"},
+		{name: "federal district", input: " df ", want: "DF"},
+		{name: "empty", input: "", wantErr: domainErrors.ErrStateRequired},
+		{name: "unknown code", input: "XX", wantErr: domainErrors.ErrStateInvalid},
+		{name: "full name", input: "São Paulo", wantErr: domainErrors.ErrStateInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUF(tt.input)
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got != tt.want {
+				t.Errorf("ParseUF(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	errors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/google/uuid"
+)
+
+const minWebhookSecretLength = 16
+
+// nonPublicPrefixes are ranges not covered by the netip predicates used in
+// IsPublicWebhookAddr that still must not receive webhooks.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// WebhookSubscription is a partner endpoint that receives proposal status
+// changes. The secret signs every payload sent to it.
+type WebhookSubscription struct {
+	ID        uuid.UUID
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// NewWebhookSubscription validates the target URL and generates a secret
+// when the partner does not provide one. Targets that are obviously internal
+// (localhost or a non-public IP literal) are rejected here; hostnames are
+// checked again against the resolved address when the webhook is sent.
+func NewWebhookSubscription(target, secret string) (*WebhookSubscription, error) {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, errors.ErrInvalidWebhookURL
+	}
+	if !isPublicWebhookHost(parsed.Hostname()) {
+		return nil, errors.ErrWebhookURLNotPublic
+	}
+
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minWebhookSecretLength {
+		return nil, errors.ErrWebhookSecretTooShort
+	}
+
+	return &WebhookSubscription{
+		ID:        uuid.New(),
+		URL:       target,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func isPublicWebhookHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return IsPublicWebhookAddr(addr)
+	}
+	return true
+}
+
+// IsPublicWebhookAddr reports whether addr may receive webhooks. Loopback,
+// private, link-local, multicast and reserved addresses are refused so a
+// subscription cannot reach internal services.
+func IsPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+func (s WebhookDeliveryStatus) IsKnown() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is one payload to be sent to one subscription. It is
+// written in the same transaction as the status change that produced it.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	ProposalID     uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewWebhookDelivery(subscriptionID, proposalID uuid.UUID, eventType string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		ProposalID:     proposalID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+func (d *WebhookDelivery) MarkDelivered() {
+	now := time.Now()
+	d.Status = WebhookDeliveryDelivered
+	d.DeliveredAt = &now
+	d.LastError = ""
+}
+
+// MarkFailed records a failed attempt. Once maxAttempts is reached the
+// delivery is dead and only a replay sends it again.
+func (d *WebhookDelivery) MarkFailed(err error, nextAttemptAt time.Time, maxAttempts int) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.NextAttemptAt = nextAttemptAt
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryDead
+	}
+}
+
+// Replay schedules a dead delivery to be sent again right away with a fresh
+// attempt budget.
+func (d *WebhookDelivery) Replay() error {
+	if d.Status != WebhookDeliveryDead {
+		return errors.ErrOnlyDeadDeliveriesCanBeReplayed
+	}
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now()
+	return nil
+}
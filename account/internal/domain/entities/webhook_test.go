@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		wantErr error
+	}{
+		{name: "public hostname", target: "https://partner.example.com/hooks"},
+		{name: "public ip with port", target: "http://8.8.8.8:8080/hooks"},
+		{name: "public ipv6", target: "https://[2001:4860:4860::8888]/hooks"},
+		{name: "ftp scheme", target: "ftp://partner.example.com", wantErr: domainErrors.ErrInvalidWebhookURL},
+		{name: "missing host", target: "https:///hooks", wantErr: domainErrors.ErrInvalidWebhookURL},
+		{name: "localhost", target: "http://localhost:8080/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "localhost subdomain", target: "http://api.localhost/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "loopback", target: "http://127.0.0.1/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "ipv6 loopback", target: "http://[::1]/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "mapped loopback", target: "http://[::ffff:127.0.0.1]/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "private", target: "http://10.0.0.5/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "private 192.168", target: "http://192.168.1.10/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "metadata endpoint", target: "http://169.254.169.254/latest/meta-data", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "unspecified", target: "http://0.0.0.0/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "shared address space", target: "http://100.64.0.1/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+		{name: "unique local ipv6", target: "http://[fd00::1]/hooks", wantErr: domainErrors.ErrWebhookURLNotPublic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWebhookSubscription(tt.target, "")
+			if tt.wantErr != nil {
+				assertErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assertNoError(t, err)
+			if got.URL != tt.target || got.Secret == "" {
+				t.Errorf("unexpected subscription %+v", got)
+			}
+		})
+	}
+}
@@ -11,14 +11,102 @@ var (
 	ErrBirthDateRequired = errors.New("birth date is required")
 )
 
+// Age eligibility errors
+var (
+	ErrBirthDateInFuture    = errors.New("birth date cannot be in the future")
+	ErrApplicantUnderage    = errors.New("applicant must be at least 18 years old")
+	ErrApplicantAboveMaxAge = errors.New("applicant is older than the maximum age accepted")
+)
+
+// CPF validation errors
+var (
+	ErrCPFInvalidFormat      = errors.New("CPF must contain only digits, dots and a dash")
+	ErrCPFInvalidLength      = errors.New("CPF must have exactly 11 digits")
+	ErrCPFRepeatedDigits     = errors.New("CPF cannot be a sequence of repeated digits")
+	ErrCPFInvalidCheckDigits = errors.New("CPF check digits are invalid")
+)
+
+// Contact validation errors
+var (
+	ErrEmailInvalid       = errors.New("email must be a valid address such as name@example.com")
+	ErrPhoneInvalidDDD    = errors.New("phone area code (DDD) does not exist")
+	ErrPhoneInvalidNumber = errors.New("phone must have a DDD and 8 digits for landlines or 9 digits starting with 9 for mobiles")
+)
+
+// Address validation errors
+var (
+	ErrZipCodeRequired = errors.New("zip code is required")
+	ErrZipCodeInvalid  = errors.New("zip code (CEP) must have exactly 8 digits")
+	ErrStateRequired   = errors.New("state is required")
+	ErrStateInvalid    = errors.New("state must be one of the 27 UF codes")
+)
+
 // Domain business logic errors
 var (
-	ErrOnlyPendingCanStartAnalysis     = errors.New("only pending proposals can start analysis")
-	ErrOnlyAnalyzingCanBeApproved      = errors.New("only analyzing proposals can be approved")
-	ErrOnlyPendingOrAnalyzingCanReject = errors.New("only pending or analyzing proposals can be rejected")
+	ErrOnlyPendingCanStartAnalysis        = errors.New("only pending proposals can start analysis")
+	ErrOnlyAnalyzingCanBeApproved         = errors.New("only analyzing proposals can be approved")
+	ErrOnlyPendingOrAnalyzingCanReject    = errors.New("only pending or analyzing proposals can be rejected")
+	ErrOnlyDeadDeliveriesCanBeReplayed    = errors.New("only dead webhook deliveries can be replayed")
+	ErrOnlyOfferPendingCanBeDecided       = errors.New("only proposals with a pending offer can be accepted, declined or expired")
+	ErrOfferExpired                       = errors.New("offer has expired")
+	ErrOfferNotExpired                    = errors.New("offer has not expired yet")
+	ErrOnlyOpenProposalsCanBeCancelled    = errors.New("only proposals that are not finalized can be cancelled")
+	ErrOnlyAnalyzingCanBeReviewed         = errors.New("only analyzing proposals can be sent to manual review")
+	ErrOnlyUnderReviewCanBeDecided        = errors.New("only proposals under review can be approved or rejected by an operator")
+	ErrOnlyPendingOrAnalyzingCanExpire    = errors.New("only pending or analyzing proposals can expire")
+	ErrOnlyPendingOrAnalyzingCanBeRetried = errors.New("only pending or analyzing proposals can have their analysis requested again")
+)
+
+// Manual review errors
+var (
+	ErrReviewOperatorRequired = errors.New("operator is required")
+	ErrReviewNotesRequired    = errors.New("review notes are required")
+)
+
+// Document upload errors
+var (
+	ErrDocumentTypeInvalid            = errors.New("document type must be identity or proof_of_income")
+	ErrDocumentEmpty                  = errors.New("document file is empty")
+	ErrDocumentTooLarge               = errors.New("document file is too large")
+	ErrDocumentContentTypeNotAllowed  = errors.New("document must be a PDF, JPEG or PNG file")
+	ErrOnlyPendingCanReceiveDocuments = errors.New("documents can only be uploaded while the proposal is pending")
+	ErrDocumentsAlreadySubmitted      = errors.New("the required documents were already submitted for analysis")
+)
+
+// Cancellation errors
+var (
+	ErrCancellationReasonRequired = errors.New("cancellation reason is required")
+)
+
+// Reapplication errors
+var (
+	ErrProposalAlreadyOpen   = errors.New("CPF already has an open proposal")
+	ErrReapplicationCooldown = errors.New("CPF was rejected recently and cannot apply again yet")
+)
+
+// Offer acceptance errors
+var (
+	ErrTermsVersionRequired = errors.New("terms version is required")
+	ErrTermsVersionMismatch = errors.New("terms version does not match the offer")
+)
+
+// Webhook validation errors
+var (
+	ErrInvalidWebhookURL     = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookURLNotPublic   = errors.New("webhook url must point to a public address")
+	ErrWebhookSecretTooShort = errors.New("webhook secret must have at least 16 characters")
+)
+
+// Card issuing errors
+var (
+	ErrInvalidCardBIN = errors.New("card BIN must have 6 to 8 digits")
 )
 
 // Domain repository errors
 var (
-	ErrProposalNotFound = errors.New("proposal not found")
+	ErrProposalNotFound            = errors.New("proposal not found")
+	ErrProposalVersionConflict     = errors.New("proposal was changed by another update")
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrAccountNotFound             = errors.New("account not found")
 )
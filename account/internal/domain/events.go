@@ -1,6 +1,12 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/google/uuid"
+)
 
 // Event types consumed by account service from risk-analysis.
 // These constants define the contract between account and risk-analysis microservices.
@@ -15,6 +21,8 @@ import "github.com/google/uuid"
 //   - CreditApproved/Rejected: Second validation step (salary threshold check)
 //   - FraudApproved/Rejected: Third validation step (CPF last digit check)
 //   - RiskAnalysisCompleted: Final result when all validations pass
+//   - ManualReviewRequired: Borderline result, with the reason and the offer
+//     an operator may approve
 const (
 	EventDocumentsApproved     = "DocumentsApproved"
 	EventDocumentsRejected     = "DocumentsRejected"
@@ -23,6 +31,7 @@ const (
 	EventFraudApproved         = "FraudApproved"
 	EventFraudRejected         = "FraudRejected"
 	EventRiskAnalysisCompleted = "RiskAnalysisCompleted"
+	EventManualReviewRequired  = "ManualReviewRequired"
 )
 
 // Event type published by account service to risk-analysis.
@@ -30,17 +39,99 @@ const (
 	EventProposalCreated = "ProposalCreated"
 )
 
+// Event types published by account service to downstream consumers (cards,
+// CRM) on the proposal-events queue whenever a proposal changes status.
+// ProposalApproved is raised when the offer is presented to the customer.
+// ProposalUnderReview carries the review reason, and ProposalCancelled the
+// customer's reason in reason_message. ProposalExpired is raised when a
+// proposal stuck in pending or analyzing passes its SLA.
+const (
+	EventProposalStatusChanged = "ProposalStatusChanged"
+	EventProposalUnderReview   = "ProposalUnderReview"
+	EventProposalApproved      = "ProposalApproved"
+	EventProposalRejected      = "ProposalRejected"
+	EventProposalOfferAccepted = "ProposalOfferAccepted"
+	EventProposalOfferDeclined = "ProposalOfferDeclined"
+	EventProposalOfferExpired  = "ProposalOfferExpired"
+	EventProposalCancelled     = "ProposalCancelled"
+	EventProposalExpired       = "ProposalExpired"
+)
+
+// DomainEvent is an event raised by the proposal aggregate and published
+// through the outbox.
+type DomainEvent interface {
+	EventName() string
+	AggregateID() uuid.UUID
+}
+
 // ProposalStatusChangedEvent represents an incoming event from risk-analysis service.
+// ReasonCode and ReasonMessage are only set on rejection and review events;
+// the offer fields are only set on RiskAnalysisCompleted and
+// ManualReviewRequired.
+//
+// risk-analysis sends the credit limit twice: "credit_limit" as a number in
+// reais, which older versions use, and "credit_limit_cents" as an integer.
+// CreditLimit is taken from credit_limit_cents when present.
 type ProposalStatusChangedEvent struct {
-	EventType  string    `json:"event_type"`
-	ProposalID uuid.UUID `json:"proposal_id"`
-	Approved   bool      `json:"approved"`
+	EventType     string      `json:"event_type"`
+	ProposalID    uuid.UUID   `json:"proposal_id"`
+	Approved      bool        `json:"approved"`
+	ReasonCode    string      `json:"reason_code,omitempty"`
+	ReasonMessage string      `json:"reason_message,omitempty"`
+	CreditLimit   money.Money `json:"credit_limit,omitempty"`
+	RiskTier      string      `json:"risk_tier,omitempty"`
+	RiskScore     int         `json:"risk_score,omitempty"`
+
+	// MessageID is the SQS message id, set by the consumer. It is not part of the contract.
+	MessageID string `json:"-"`
 }
 
+func (e *ProposalStatusChangedEvent) UnmarshalJSON(data []byte) error {
+	type event ProposalStatusChangedEvent
+	var decoded struct {
+		event
+		CreditLimitCents *int64 `json:"credit_limit_cents"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*e = ProposalStatusChangedEvent(decoded.event)
+	if decoded.CreditLimitCents != nil {
+		e.CreditLimit = money.FromCents(*decoded.CreditLimitCents)
+	}
+	return nil
+}
+
+// ProposalPayload carries the applicant data risk-analysis needs. BirthDate
+// is a YYYY-MM-DD date. Documents describes the uploaded files; their
+// content stays in the account service document store.
+//
+// Salary stays a JSON number in reais, with at most 2 decimals, so consumers
+// still decoding it as float64 keep working. SalaryCents carries the same
+// amount as an integer; consumers should prefer it when present.
 type ProposalPayload struct {
-	FullName string  `json:"full_name"`
-	CPF      string  `json:"cpf"`
-	Salary   float64 `json:"salary"`
+	FullName    string             `json:"full_name"`
+	CPF         string             `json:"cpf"`
+	Salary      money.Money        `json:"salary"`
+	SalaryCents int64              `json:"salary_cents"`
+	BirthDate   string             `json:"birth_date,omitempty"`
+	Documents   []DocumentMetadata `json:"documents,omitempty"`
+}
+
+// DocumentMetadata describes an uploaded document. SHA256 is the hex digest
+// of the file content. Width and Height are only sent for images and Pages
+// for PDFs; zero means the file could not be read. UsedByOtherCPF is set
+// when the same file was uploaded for a proposal of another CPF.
+type DocumentMetadata struct {
+	ID             uuid.UUID `json:"id"`
+	Type           string    `json:"type"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	SHA256         string    `json:"sha256"`
+	Width          int       `json:"width,omitempty"`
+	Height         int       `json:"height,omitempty"`
+	Pages          int       `json:"pages,omitempty"`
+	UsedByOtherCPF bool      `json:"used_by_other_cpf"`
 }
 
 // ProposalCreatedEvent represents an outgoing event to risk-analysis service.
@@ -50,3 +141,37 @@ type ProposalCreatedEvent struct {
 	ProposalID uuid.UUID        `json:"proposal_id"`
 	Payload    *ProposalPayload `json:"payload"`
 }
+
+func (e *ProposalCreatedEvent) EventName() string {
+	return e.EventType
+}
+
+func (e *ProposalCreatedEvent) AggregateID() uuid.UUID {
+	return e.ProposalID
+}
+
+// ProposalLifecycleEvent is published on every status transition.
+// ProposalStatusChanged is always emitted; the approval, rejection and offer
+// events follow it for the matching statuses. The credit limit is sent in
+// reais and, as CreditLimitCents, as an integer; consumers should prefer
+// the latter.
+type ProposalLifecycleEvent struct {
+	EventType        string      `json:"event_type"`
+	ProposalID       uuid.UUID   `json:"proposal_id"`
+	PreviousStatus   string      `json:"previous_status"`
+	Status           string      `json:"status"`
+	ReasonCode       string      `json:"reason_code,omitempty"`
+	ReasonMessage    string      `json:"reason_message,omitempty"`
+	CreditLimit      money.Money `json:"credit_limit,omitempty"`
+	CreditLimitCents int64       `json:"credit_limit_cents,omitempty"`
+	RiskTier         string      `json:"risk_tier,omitempty"`
+	OccurredAt       time.Time   `json:"occurred_at"`
+}
+
+func (e *ProposalLifecycleEvent) EventName() string {
+	return e.EventType
+}
+
+func (e *ProposalLifecycleEvent) AggregateID() uuid.UUID {
+	return e.ProposalID
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+)
+
+func TestProposalStatusChangedEventCreditLimitDecoding(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want money.Money
+	}{
+		{name: "credit limit cents preferred", json: `{"credit_limit": 1800.00, "credit_limit_cents": 180000}`, want: 180000},
+		{name: "credit limit only, as sent by older risk-analysis versions", json: `{"credit_limit": 1800.5}`, want: 180050},
+		{name: "no offer", json: `{"event_type": "CreditRejected"}`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event ProposalStatusChangedEvent
+			if err := json.Unmarshal([]byte(tt.json), &event); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.CreditLimit != tt.want {
+				t.Errorf("CreditLimit = %d, want %d", event.CreditLimit, tt.want)
+			}
+		})
+	}
+}
+
+func TestProposalLifecycleEventCreditLimitEncoding(t *testing.T) {
+	data, err := json.Marshal(&ProposalLifecycleEvent{CreditLimit: money.MustParse("1800.50"), CreditLimitCents: 180050})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded["credit_limit"] != 1800.5 || decoded["credit_limit_cents"] != float64(180050) {
+		t.Errorf("unexpected credit limit fields in %s", data)
+	}
+}
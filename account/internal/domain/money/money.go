@@ -0,0 +1,116 @@
+// Package money represents Brazilian real amounts exactly, as an integer
+// number of centavos. The same type is implemented in
+// risk-analysis/internal/domain/money.go.
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidAmount = errors.New("amount must be a decimal number with at most 2 decimal places")
+
+// Money is an amount in centavos.
+type Money int64
+
+var centsPerReal = big.NewRat(100, 1)
+
+// decimalAmount is the only syntax accepted by Parse. big.Rat also reads
+// fractions ("1/2") and hex or binary numbers, and huge exponents are costly
+// to expand, so the text is checked before it is parsed.
+var decimalAmount = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d{1,3})?$`)
+
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// Parse reads a decimal amount in reais ("3000.01", "3000", "3e3") without
+// going through float64. Amounts with fractions of a centavo are rejected.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if !decimalAmount.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+	amount, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	cents := amount.Mul(amount, centsPerReal)
+	if !cents.IsInt() || !cents.Num().IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return Money(cents.Num().Int64()), nil
+}
+
+// MustParse is Parse for constants; it panics on an invalid amount.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("money: %q: %v", s, err))
+	}
+	return m
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Float64 is for display and ratios only; comparisons must use Money.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String renders the amount in reais with two decimals, as in "3000.01".
+func (m Money) String() string {
+	sign, cents := "", int64(m)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// BRL renders the amount in Brazilian format, as in "R$ 3.000,01".
+func (m Money) BRL() string {
+	sign, cents := "", int64(m)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	reais := strconv.FormatInt(cents/100, 10)
+	var grouped strings.Builder
+	for i, digit := range reais {
+		if i > 0 && (len(reais)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+// MarshalJSON writes the amount as a JSON number in reais (3000.01), the
+// format used before Money existed.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number (3000.01) or string
+// ("3000.01"). Numbers are parsed from their text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
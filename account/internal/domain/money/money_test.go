@@ -0,0 +1,118 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "3000.01", want: 300001},
+		{input: "3000", want: 300000},
+		{input: "0.1", want: 10},
+		{input: " 12.50 ", want: 1250},
+		{input: "3e3", want: 300000},
+		{input: "-5.25", want: -525},
+		{input: "3000.001", wantErr: true},
+		{input: "3.000,01", wantErr: true},
+		{input: "R$ 10", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "1e30", wantErr: true},
+		{input: "1/2", wantErr: true},
+		{input: "0x10", wantErr: true},
+		{input: "0b1", wantErr: true},
+		{input: "1e999999", wantErr: true},
+		{input: ".5", wantErr: true},
+		{input: "+5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Errorf("Parse(%q) error = %v, want ErrInvalidAmount", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Parse(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsHugeExponentsQuickly(t *testing.T) {
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if _, err := Parse("1e999999"); !errors.Is(err, ErrInvalidAmount) {
+			t.Fatalf("expected ErrInvalidAmount, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected huge exponents to be rejected without expanding them, took %v", elapsed)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	tests := []struct {
+		amount     Money
+		wantString string
+		wantBRL    string
+	}{
+		{amount: 300001, wantString: "3000.01", wantBRL: "R$ 3.000,01"},
+		{amount: 5, wantString: "0.05", wantBRL: "R$ 0,05"},
+		{amount: 123456789012, wantString: "1234567890.12", wantBRL: "R$ 1.234.567.890,12"},
+		{amount: -150, wantString: "-1.50", wantBRL: "-R$ 1,50"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.String(); got != tt.wantString {
+			t.Errorf("String() = %q, want %q", got, tt.wantString)
+		}
+		if got := tt.amount.BRL(); got != tt.wantBRL {
+			t.Errorf("BRL() = %q, want %q", got, tt.wantBRL)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var body struct {
+		Number Money `json:"number"`
+		Text   Money `json:"text"`
+	}
+	if err := json.Unmarshal([]byte(`{"number": 3000.01, "text": "4500.50"}`), &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Number != 300001 || body.Text != 450050 {
+		t.Errorf("expected 300001 and 450050, got %d and %d", body.Number, body.Text)
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(encoded) != `{"number":3000.01,"text":4500.50}` {
+		t.Errorf("unexpected JSON %s", encoded)
+	}
+
+	var invalid Money
+	if err := json.Unmarshal([]byte(`"12.345"`), &invalid); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+}
+
+func TestJSONRejectsNonDecimalStrings(t *testing.T) {
+	for _, input := range []string{`"1/2"`, `"0x10"`, `"0b1"`, `"1e999999"`} {
+		var amount Money
+		if err := json.Unmarshal([]byte(input), &amount); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Unmarshal(%s) error = %v, want ErrInvalidAmount", input, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "strings"
+
+// FieldError ties a validation error to the field that caused it. Field is a
+// dotted path such as "address.zip_code".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors collects every field that failed validation, so callers
+// can report them all at once. errors.Is matches any of the wrapped errors.
+type ValidationErrors []FieldError
+
+// Add records err against field when err is not nil.
+func (v *ValidationErrors) Add(field string, err error) {
+	if err != nil {
+		*v = append(*v, FieldError{Field: field, Err: err})
+	}
+}
+
+// Err returns nil when no field failed, so the result can be returned directly.
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, e := range v {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, e := range v {
+		errs[i] = e
+	}
+	return errs
+}
@@ -0,0 +1,28 @@
+package cardvault
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// HMACTokenizer derives card tokens with HMAC-SHA256, so the same PAN always
+// maps to the same token and the PAN itself is never stored.
+type HMACTokenizer struct {
+	key []byte
+}
+
+func NewHMACTokenizer(key string) (*HMACTokenizer, error) {
+	if len(key) < 32 {
+		return nil, fmt.Errorf("CARD_TOKEN_KEY must have at least 32 characters")
+	}
+	return &HMACTokenizer{key: []byte(key)}, nil
+}
+
+func (t *HMACTokenizer) Tokenize(ctx context.Context, pan string) (string, error) {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(pan))
+	return "tok_" + hex.EncodeToString(mac.Sum(nil)), nil
+}
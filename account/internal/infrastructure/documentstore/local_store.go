@@ -0,0 +1,73 @@
+package documentstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps documents as files under a root directory. It is meant
+// for development and single-replica deployments.
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if root == "" {
+		return nil, errors.New("document store directory is required")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("create document store directory: %w", err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// Put writes to a temporary file first, so a partially written document is
+// never visible under its key.
+func (s *LocalStore) Put(ctx context.Context, key string, contentType string, content []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create document directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create document file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write document: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write document: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.root)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid document key %q", key)
+	}
+	return path, nil
+}
@@ -0,0 +1,198 @@
+package documentstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const maxErrorBodyBytes = 1 << 10
+
+// S3Config points S3Store at a bucket. Endpoint is the base URL of any
+// S3-compatible service, such as http://minio:9000 or
+// https://s3.sa-east-1.amazonaws.com; objects are addressed path-style.
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Timeout   time.Duration
+}
+
+// S3Store keeps documents as objects in an S3-compatible bucket. Requests
+// are signed with AWS Signature Version 4.
+type S3Store struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &S3Store{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		region:    cfg.Region,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		client:    &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, contentType string, content []byte) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return s.do(req, content)
+}
+
+// Delete succeeds when the object does not exist.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, content []byte) (*http.Request, error) {
+	target := *s.endpoint
+	target.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	target.RawPath = s.endpoint.Path + "/" + uriEncode(s.bucket, false) + "/" + uriEncode(key, true)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.ContentLength = int64(len(content))
+	return req, nil
+}
+
+func (s *S3Store) do(req *http.Request, content []byte) error {
+	s.sign(req, content, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 %s: %w", req.Method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("s3 error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 headers. Host, the x-amz-* headers and
+// Content-Type, when set, are signed.
+func (s *S3Store) sign(req *http.Request, content []byte, now time.Time) {
+	payloadHash := sha256Hex(content)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+		signedHeaders = "content-type;" + signedHeaders
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// uriEncode escapes every byte except the unreserved characters of RFC 3986,
+// as Signature Version 4 requires. Slashes are kept when keepSlash is set.
+func uriEncode(value string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
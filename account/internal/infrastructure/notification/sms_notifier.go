@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+type SMSConfig struct {
+	APIURL string
+	Sender string
+}
+
+// SMSNotifier posts messages to an HTTP SMS gateway. Locally it points to a
+// fake sink that only logs the requests.
+type SMSNotifier struct {
+	apiURL string
+	sender string
+	client *http.Client
+}
+
+func NewSMSNotifier(cfg SMSConfig) (*SMSNotifier, error) {
+	if cfg.APIURL == "" {
+		return nil, fmt.Errorf("SMS_API_URL is required")
+	}
+
+	return &SMSNotifier{
+		apiURL: cfg.APIURL,
+		sender: cfg.Sender,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (n *SMSNotifier) Channel() string {
+	return ports.ChannelSMS
+}
+
+func (n *SMSNotifier) Send(ctx context.Context, message *ports.Message) error {
+	body, err := json.Marshal(map[string]string{
+		"from":    n.sender,
+		"to":      message.Recipient,
+		"message": message.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal sms: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("sms gateway error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
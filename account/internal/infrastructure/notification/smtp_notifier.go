@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+// DefaultSMTPTimeout bounds a whole delivery, from dialing to QUIT.
+const DefaultSMTPTimeout = 10 * time.Second
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	From     string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// SMTPNotifier sends plain text emails. Each delivery must finish within
+// Timeout or the context deadline, whichever comes first, so a hung server
+// does not stall the caller.
+type SMTPNotifier struct {
+	host    string
+	addr    string
+	from    string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is required")
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("SMTP_FROM is required")
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = "25"
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultSMTPTimeout
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPNotifier{
+		host:    cfg.Host,
+		addr:    net.JoinHostPort(cfg.Host, port),
+		from:    cfg.From,
+		auth:    auth,
+		timeout: timeout,
+	}, nil
+}
+
+func (n *SMTPNotifier) Channel() string {
+	return ports.ChannelEmail
+}
+
+func (n *SMTPNotifier) Send(ctx context.Context, message *ports.Message) error {
+	if strings.ContainsAny(message.Recipient, "\r\n") {
+		return fmt.Errorf("invalid recipient")
+	}
+
+	var body strings.Builder
+	body.WriteString("From: " + n.from + "\r\n")
+	body.WriteString("To: " + message.Recipient + "\r\n")
+	body.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	if err := n.deliver(ctx, message.Recipient, []byte(body.String())); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// deliver does what smtp.SendMail does, on a connection whose dial and I/O
+// are bounded by the timeout and ctx.
+func (n *SMTPNotifier) deliver(ctx context.Context, recipient string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	// Closing the connection unblocks a pending read or write when ctx is
+	// cancelled before the deadline.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if err := client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+func TestSMTPNotifierSendTimesOutOnHungServer(t *testing.T) {
+	// The server accepts the connection but never sends its greeting.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: host, Port: port, From: "no-reply@propostas.local", Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	err = notifier.Send(context.Background(), &ports.Message{Recipient: "john@example.com", Subject: "Proposta", Body: "ok"})
+
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Send to give up after the timeout, took %v", elapsed)
+	}
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccountRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+func (r *AccountRepository) NextAccountNumber(ctx context.Context) (int64, error) {
+	var number int64
+	err := conn(ctx, r.db).QueryRow(ctx, "SELECT nextval('customer_account_number_seq')").Scan(&number)
+	return number, err
+}
+
+func (r *AccountRepository) Save(ctx context.Context, account *entities.CustomerAccount) error {
+	const query = `
+		INSERT INTO customer_accounts (
+			id,
+			proposal_id,
+			branch,
+			number,
+			check_digit,
+			created_at
+		) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		account.ID,
+		account.ProposalID,
+		account.Branch,
+		account.Number,
+		account.CheckDigit,
+		account.CreatedAt,
+	)
+	return err
+}
+
+func (r *AccountRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) (*entities.CustomerAccount, error) {
+	const query = `
+		SELECT id, proposal_id, branch, number, check_digit, created_at
+		FROM customer_accounts
+		WHERE proposal_id = $1`
+
+	var a entities.CustomerAccount
+	err := conn(ctx, r.db).QueryRow(ctx, query, proposalID).Scan(
+		&a.ID,
+		&a.ProposalID,
+		&a.Branch,
+		&a.Number,
+		&a.CheckDigit,
+		&a.CreatedAt,
+	)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, domainErrors.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+type CardRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCardRepository(db *pgxpool.Pool) *CardRepository {
+	return &CardRepository{db: db}
+}
+
+func (r *CardRepository) Save(ctx context.Context, card *entities.Card) error {
+	const query = `
+		INSERT INTO cards (
+			id,
+			account_id,
+			proposal_id,
+			token,
+			masked_pan,
+			expiry_month,
+			expiry_year,
+			status,
+			created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		card.ID,
+		card.AccountID,
+		card.ProposalID,
+		card.Token,
+		card.MaskedPAN,
+		card.ExpiryMonth,
+		card.ExpiryYear,
+		card.Status,
+		card.CreatedAt,
+	)
+	return err
+}
+
+func (r *CardRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entities.Card, error) {
+	const query = `
+		SELECT
+			id,
+			account_id,
+			proposal_id,
+			token,
+			masked_pan,
+			expiry_month,
+			expiry_year,
+			status,
+			created_at
+		FROM cards
+		WHERE account_id = $1
+		ORDER BY created_at`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []*entities.Card
+	for rows.Next() {
+		var c entities.Card
+		var status string
+		if err := rows.Scan(
+			&c.ID,
+			&c.AccountID,
+			&c.ProposalID,
+			&c.Token,
+			&c.MaskedPAN,
+			&c.ExpiryMonth,
+			&c.ExpiryYear,
+			&status,
+			&c.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		c.Status = entities.CardStatus(status)
+		cards = append(cards, &c)
+	}
+	return cards, rows.Err()
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLock implements ports.JobLock with session-level Postgres advisory
+// locks. The lock lives on a dedicated connection, so it is released by the
+// server if the replica holding it dies.
+type AdvisoryLock struct {
+	db *pgxpool.Pool
+}
+
+func NewAdvisoryLock(db *pgxpool.Pool) *AdvisoryLock {
+	return &AdvisoryLock{db: db}
+}
+
+func (l *AdvisoryLock) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	c, err := l.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	if err := c.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&acquired); err != nil {
+		c.Release()
+		return nil, false, err
+	}
+	if !acquired {
+		c.Release()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// The lock must be released even when ctx was cancelled. If the unlock
+		// fails, the connection is closed so the server drops the lock.
+		if _, err := c.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, name); err != nil {
+			c.Conn().Close(context.Background())
+		}
+		c.Release()
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DocumentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDocumentRepository(db *pgxpool.Pool) *DocumentRepository {
+	return &DocumentRepository{db: db}
+}
+
+func (r *DocumentRepository) Save(ctx context.Context, document *entities.Document) error {
+	const query = `
+		INSERT INTO proposal_documents (
+			id,
+			proposal_id,
+			type,
+			file_name,
+			content_type,
+			size_bytes,
+			sha256,
+			width,
+			height,
+			page_count,
+			storage_key,
+			uploaded_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		document.ID,
+		document.ProposalID,
+		document.Type,
+		document.FileName,
+		document.ContentType,
+		document.Size,
+		document.SHA256,
+		document.Width,
+		document.Height,
+		document.Pages,
+		document.StorageKey,
+		document.UploadedAt,
+	)
+	return err
+}
+
+func (r *DocumentRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.Document, error) {
+	const query = `
+		SELECT
+			id,
+			proposal_id,
+			type,
+			file_name,
+			content_type,
+			size_bytes,
+			sha256,
+			width,
+			height,
+			page_count,
+			storage_key,
+			uploaded_at
+		FROM proposal_documents
+		WHERE proposal_id = $1
+		ORDER BY uploaded_at, id`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []*entities.Document
+	for rows.Next() {
+		var document entities.Document
+		var docType string
+		if err := rows.Scan(
+			&document.ID,
+			&document.ProposalID,
+			&docType,
+			&document.FileName,
+			&document.ContentType,
+			&document.Size,
+			&document.SHA256,
+			&document.Width,
+			&document.Height,
+			&document.Pages,
+			&document.StorageKey,
+			&document.UploadedAt,
+		); err != nil {
+			return nil, err
+		}
+		document.Type = entities.DocumentType(docType)
+		documents = append(documents, &document)
+	}
+	return documents, rows.Err()
+}
+
+// ExistsForOtherCPF reports whether a file with the same hash was uploaded
+// for a proposal of a different CPF.
+func (r *DocumentRepository) ExistsForOtherCPF(ctx context.Context, sha256 string, cpf string) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM proposal_documents d
+			JOIN proposals p ON p.id = d.proposal_id
+			WHERE d.sha256 = $1 AND p.cpf <> $2
+		)`
+
+	var exists bool
+	err := conn(ctx, r.db).QueryRow(ctx, query, sha256, cpf).Scan(&exists)
+	return exists, err
+}
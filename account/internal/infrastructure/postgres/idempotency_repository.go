@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *ports.IdempotencyRecord) (*ports.IdempotencyRecord, error) {
+	// Expired keys are taken over by the new request.
+	const insert = `
+		INSERT INTO idempotency_keys (
+			key,
+			request_hash,
+			created_at,
+			expires_at
+		) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`
+
+	cmd, err := conn(ctx, r.db).Exec(ctx, insert,
+		record.Key,
+		record.RequestHash,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.RowsAffected() == 1 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT
+			key,
+			request_hash,
+			COALESCE(status_code, 0),
+			COALESCE(content_type, ''),
+			response_body,
+			created_at,
+			expires_at
+		FROM idempotency_keys
+		WHERE key = $1`
+
+	var existing ports.IdempotencyRecord
+	err = conn(ctx, r.db).QueryRow(ctx, query, record.Key).Scan(
+		&existing.Key,
+		&existing.RequestHash,
+		&existing.StatusCode,
+		&existing.ContentType,
+		&existing.ResponseBody,
+		&existing.CreatedAt,
+		&existing.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *ports.IdempotencyRecord) error {
+	const query = `
+		UPDATE idempotency_keys SET
+			status_code = $2,
+			content_type = $3,
+			response_body = $4,
+			expires_at = $5
+		WHERE key = $1 AND created_at = $6`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		record.Key,
+		record.StatusCode,
+		record.ContentType,
+		record.ResponseBody,
+		record.ExpiresAt,
+		record.CreatedAt,
+	)
+	return err
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, record *ports.IdempotencyRecord) error {
+	const query = `DELETE FROM idempotency_keys WHERE key = $1 AND created_at = $2`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, record.Key, record.CreatedAt)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE key IN (
+			SELECT key FROM idempotency_keys
+			WHERE expires_at <= $1
+			LIMIT $2
+		)`
+
+	cmd, err := conn(ctx, r.db).Exec(ctx, query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(cmd.RowsAffected()), nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/money"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// numericFromMoney encodes an amount for a DECIMAL column. Passing Money
+// directly would store the centavos as reais.
+func numericFromMoney(m money.Money) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(m.Cents()), Exp: -2, Valid: true}
+}
+
+// moneyFromNumeric decodes a DECIMAL column without going through float64.
+func moneyFromNumeric(n pgtype.Numeric) (money.Money, error) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite {
+		return 0, fmt.Errorf("amount %v is not a finite number", n)
+	}
+	cents := new(big.Int).Set(n.Int)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n.Exp+2))), nil)
+	if n.Exp+2 >= 0 {
+		cents.Mul(cents, scale)
+	} else {
+		var remainder big.Int
+		cents.QuoRem(cents, scale, &remainder)
+		if remainder.Sign() != 0 {
+			return 0, fmt.Errorf("amount %v has fractions of a centavo", n)
+		}
+	}
+	if !cents.IsInt64() {
+		return 0, fmt.Errorf("amount %v is out of range", n)
+	}
+	return money.FromCents(cents.Int64()), nil
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationLogRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationLogRepository(db *pgxpool.Pool) *NotificationLogRepository {
+	return &NotificationLogRepository{db: db}
+}
+
+func (r *NotificationLogRepository) Save(ctx context.Context, delivery *entities.NotificationDelivery) error {
+	const query = `
+		INSERT INTO notification_deliveries (
+			id,
+			proposal_id,
+			channel,
+			recipient,
+			template,
+			status,
+			error,
+			created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,NULLIF($7, ''),$8)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		delivery.ID,
+		delivery.ProposalID,
+		delivery.Channel,
+		delivery.Recipient,
+		delivery.Template,
+		delivery.Status,
+		delivery.Error,
+		delivery.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OfferEvidenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOfferEvidenceRepository(db *pgxpool.Pool) *OfferEvidenceRepository {
+	return &OfferEvidenceRepository{db: db}
+}
+
+func (r *OfferEvidenceRepository) Save(ctx context.Context, evidence *entities.OfferEvidence) error {
+	const query = `
+		INSERT INTO offer_evidences (
+			id,
+			proposal_id,
+			decision,
+			terms_version,
+			ip_address,
+			forwarded_for,
+			user_agent,
+			decided_at
+		) VALUES ($1,$2,$3,NULLIF($4, ''),$5,NULLIF($6, ''),$7,$8)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		evidence.ID,
+		evidence.ProposalID,
+		evidence.Decision,
+		evidence.TermsVersion,
+		evidence.IPAddress,
+		evidence.ForwardedFor,
+		evidence.UserAgent,
+		evidence.DecidedAt,
+	)
+	return err
+}
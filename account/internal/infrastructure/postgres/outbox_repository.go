@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, message *entities.OutboxMessage) error {
+	const query = `
+		INSERT INTO outbox_messages (
+			id,
+			destination,
+			aggregate_id,
+			event_type,
+			payload,
+			attempts,
+			next_attempt_at,
+			created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		message.ID,
+		message.Destination,
+		message.AggregateID,
+		message.EventType,
+		message.Payload,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.CreatedAt,
+	)
+	return err
+}
+
+// FetchPending locks due messages so concurrent relays skip them. It must be
+// called inside a transaction for the lock to outlive the query.
+func (r *OutboxRepository) FetchPending(ctx context.Context, limit int) ([]*entities.OutboxMessage, error) {
+	const query = `
+		SELECT
+			id,
+			destination,
+			aggregate_id,
+			event_type,
+			payload,
+			attempts,
+			COALESCE(last_error, ''),
+			next_attempt_at,
+			created_at,
+			sent_at
+		FROM outbox_messages
+		WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY created_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*entities.OutboxMessage
+	for rows.Next() {
+		var m entities.OutboxMessage
+		if err := rows.Scan(
+			&m.ID,
+			&m.Destination,
+			&m.AggregateID,
+			&m.EventType,
+			&m.Payload,
+			&m.Attempts,
+			&m.LastError,
+			&m.NextAttemptAt,
+			&m.CreatedAt,
+			&m.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &m)
+	}
+	return messages, rows.Err()
+}
+
+func (r *OutboxRepository) Update(ctx context.Context, message *entities.OutboxMessage) error {
+	const query = `
+		UPDATE outbox_messages SET
+			attempts = $2,
+			last_error = NULLIF($3, ''),
+			next_attempt_at = $4,
+			sent_at = $5
+		WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		message.ID,
+		message.Attempts,
+		message.LastError,
+		message.NextAttemptAt,
+		message.SentAt,
+	)
+	return err
+}
+
+func (r *OutboxRepository) Stats(ctx context.Context, stuckAttempts int) (*ports.OutboxStats, error) {
+	const query = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE attempts >= $1),
+			MIN(created_at)
+		FROM outbox_messages
+		WHERE sent_at IS NULL`
+
+	var stats ports.OutboxStats
+	err := conn(ctx, r.db).QueryRow(ctx, query, stuckAttempts).Scan(
+		&stats.Pending,
+		&stats.Stuck,
+		&stats.OldestPending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	proposalEventsChannel   = "proposal_events"
+	subscriberBufferSize    = 16
+	listenReconnectInterval = time.Second
+)
+
+// ProposalEventBroker fans proposal events out to every replica through
+// Postgres LISTEN/NOTIFY. Events published inside a transaction are only
+// delivered once it commits.
+type ProposalEventBroker struct {
+	db          *pgxpool.Pool
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan *ports.ProposalEvent]struct{}
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewProposalEventBroker(db *pgxpool.Pool) *ProposalEventBroker {
+	return &ProposalEventBroker{
+		db:          db,
+		subscribers: make(map[uuid.UUID]map[chan *ports.ProposalEvent]struct{}),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (b *ProposalEventBroker) Publish(ctx context.Context, event *ports.ProposalEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, b.db).Exec(ctx, "SELECT pg_notify($1, $2)", proposalEventsChannel, string(payload))
+	return err
+}
+
+func (b *ProposalEventBroker) Subscribe(ctx context.Context, proposalID uuid.UUID) (<-chan *ports.ProposalEvent, func(), error) {
+	ch := make(chan *ports.ProposalEvent, subscriberBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[proposalID] == nil {
+		b.subscribers[proposalID] = make(map[chan *ports.ProposalEvent]struct{})
+	}
+	b.subscribers[proposalID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			// dispatch already closed the channel of a lagging subscriber.
+			if _, ok := b.subscribers[proposalID][ch]; ok {
+				b.unsubscribe(proposalID, ch)
+			}
+		})
+	}
+	return ch, cancel, nil
+}
+
+func (b *ProposalEventBroker) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		<-b.stopCh
+		cancel()
+	}()
+
+	b.wg.Add(1)
+	go b.run(ctx)
+	return nil
+}
+
+func (b *ProposalEventBroker) Stop() error {
+	close(b.stopCh)
+	b.wg.Wait()
+	return nil
+}
+
+func (b *ProposalEventBroker) run(ctx context.Context) {
+	defer b.wg.Done()
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[Account] Proposal event listener stopped, reconnecting: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenReconnectInterval):
+		}
+	}
+}
+
+// listen holds a dedicated connection outside the pool, since LISTEN is
+// bound to the session.
+func (b *ProposalEventBroker) listen(ctx context.Context) error {
+	pooled, err := b.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	c := pooled.Hijack()
+	defer c.Close(context.Background())
+
+	if _, err := c.Exec(ctx, "LISTEN "+proposalEventsChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := c.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event ports.ProposalEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("[Account] Invalid proposal event payload: %v", err)
+			continue
+		}
+		b.dispatch(&event)
+	}
+}
+
+// dispatch never blocks the listener. A subscriber whose buffer is full has
+// fallen behind: its channel is closed instead of silently dropping the
+// event, which may be the final one, so its stream ends and the client
+// reconnects and starts again from a fresh snapshot.
+func (b *ProposalEventBroker) dispatch(event *ports.ProposalEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[event.ProposalID] {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("[Account] Proposal event subscriber fell behind, closing its stream: proposal_id=%s", event.ProposalID)
+			b.unsubscribe(event.ProposalID, ch)
+		}
+	}
+}
+
+// unsubscribe must be called with mu held.
+func (b *ProposalEventBroker) unsubscribe(proposalID uuid.UUID, ch chan *ports.ProposalEvent) {
+	delete(b.subscribers[proposalID], ch)
+	if len(b.subscribers[proposalID]) == 0 {
+		delete(b.subscribers, proposalID)
+	}
+	close(ch)
+}
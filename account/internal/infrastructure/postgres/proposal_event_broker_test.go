@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+)
+
+func TestProposalEventBroker_Dispatch(t *testing.T) {
+	t.Run("should close the stream of a subscriber that fell behind", func(t *testing.T) {
+		broker := NewProposalEventBroker(nil)
+		proposalID := uuid.New()
+		slow, cancelSlow, _ := broker.Subscribe(context.Background(), proposalID)
+		fast, cancelFast, _ := broker.Subscribe(context.Background(), proposalID)
+		defer cancelFast()
+
+		for i := 0; i <= subscriberBufferSize; i++ {
+			broker.dispatch(&ports.ProposalEvent{ProposalID: proposalID})
+			<-fast
+		}
+
+		received := 0
+		for range slow {
+			received++
+		}
+		if received != subscriberBufferSize {
+			t.Errorf("expected %d buffered events before the stream closed, got %d", subscriberBufferSize, received)
+		}
+		cancelSlow()
+
+		broker.dispatch(&ports.ProposalEvent{ProposalID: proposalID, Final: true})
+		if event := <-fast; !event.Final {
+			t.Error("expected the other subscriber to keep receiving events")
+		}
+	})
+}
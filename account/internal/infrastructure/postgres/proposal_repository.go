@@ -3,14 +3,53 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const selectProposal = `
+		SELECT
+			id,
+			full_name,
+			cpf,
+			salary,
+			email,
+			phone,
+			birthdate,
+			address_street,
+			address_city,
+			address_state,
+			address_zip,
+			status,
+			rejection_code,
+			rejection_message,
+			offer_credit_limit,
+			offer_risk_tier,
+			offer_risk_score,
+			offer_annual_fee,
+			offer_terms_version,
+			offer_expires_at,
+			cancellation_reason,
+			review_code,
+			review_message,
+			analysis_retries,
+			version,
+			created_at,
+			updated_at
+		FROM proposals`
+
+// openCPFIndex allows a single open proposal per CPF.
+const openCPFIndex = "uq_proposals_open_cpf"
+
 type ProposalRepository struct {
 	db *pgxpool.Pool
 }
@@ -34,15 +73,16 @@ func (r *ProposalRepository) Save(ctx context.Context, proposal *entities.Propos
 			address_state,
 			address_zip,
 			status,
+			version,
 			created_at,
 			updated_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := conn(ctx, r.db).Exec(ctx, query,
 		proposal.ID,
 		proposal.FullName,
 		proposal.CPF,
-		proposal.Salary,
+		numericFromMoney(proposal.Salary),
 		proposal.Email,
 		proposal.Phone,
 		proposal.BirthDate,
@@ -51,9 +91,13 @@ func (r *ProposalRepository) Save(ctx context.Context, proposal *entities.Propos
 		proposal.Address.State,
 		proposal.Address.ZipCode,
 		proposal.Status,
+		proposal.Version,
 		proposal.CreatedAt,
 		proposal.UpdatedAt,
 	)
+	if isUniqueViolation(err, openCPFIndex) {
+		return domainErrors.ErrProposalAlreadyOpen
+	}
 	return err
 }
 
@@ -61,80 +105,196 @@ func (r *ProposalRepository) Update(ctx context.Context, proposal *entities.Prop
 	const query = `
 		UPDATE proposals SET
 			status = $2,
-			updated_at = $3
-		WHERE id = $1`
+			rejection_code = $3,
+			rejection_message = $4,
+			offer_credit_limit = $5,
+			offer_risk_tier = $6,
+			offer_risk_score = $7,
+			offer_annual_fee = $8,
+			offer_terms_version = $9,
+			offer_expires_at = $10,
+			cancellation_reason = $11,
+			review_code = $12,
+			review_message = $13,
+			analysis_retries = $14,
+			updated_at = $15,
+			version = version + 1
+		WHERE id = $1 AND version = $16`
+
+	var rejectionCode, rejectionMessage *string
+	if proposal.Rejection != nil {
+		rejectionCode = &proposal.Rejection.Code
+		rejectionMessage = &proposal.Rejection.Message
+	}
+	var cancellationReason *string
+	if proposal.CancellationReason != "" {
+		cancellationReason = &proposal.CancellationReason
+	}
+	var reviewCode, reviewMessage *string
+	if proposal.Review != nil {
+		reviewCode = &proposal.Review.Code
+		reviewMessage = &proposal.Review.Message
+	}
+	var creditLimit, annualFee pgtype.Numeric
+	var riskTier, termsVersion *string
+	var riskScore *int
+	var expiresAt *time.Time
+	if offer := proposal.Offer; offer != nil {
+		creditLimit = numericFromMoney(offer.CreditLimit)
+		riskTier = &offer.RiskTier
+		riskScore = &offer.RiskScore
+		annualFee = numericFromMoney(offer.AnnualFee)
+		termsVersion = &offer.TermsVersion
+		if !offer.ExpiresAt.IsZero() {
+			expiresAt = &offer.ExpiresAt
+		}
+	}
 
-	cmd, err := r.db.Exec(ctx, query,
+	cmd, err := conn(ctx, r.db).Exec(ctx, query,
 		proposal.ID,
 		proposal.Status,
+		rejectionCode,
+		rejectionMessage,
+		creditLimit,
+		riskTier,
+		riskScore,
+		annualFee,
+		termsVersion,
+		expiresAt,
+		cancellationReason,
+		reviewCode,
+		reviewMessage,
+		proposal.AnalysisRetries,
 		proposal.UpdatedAt,
+		proposal.Version,
 	)
 	if err != nil {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return domainErrors.ErrProposalNotFound
+		return r.updateMissError(ctx, proposal)
 	}
+	proposal.Version++
 	return nil
 }
 
+// updateMissError tells a proposal that does not exist from one updated by
+// someone else since it was read.
+func (r *ProposalRepository) updateMissError(ctx context.Context, proposal *entities.Proposal) error {
+	const query = `SELECT EXISTS (SELECT 1 FROM proposals WHERE id = $1)`
+
+	var exists bool
+	if err := conn(ctx, r.db).QueryRow(ctx, query, proposal.ID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domainErrors.ErrProposalNotFound
+	}
+	return domainErrors.ErrProposalVersionConflict
+}
+
 func (r *ProposalRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Proposal, error) {
-	const query = `
-		SELECT
-			id,
-			full_name,
-			cpf,
-			salary,
-			email,
-			phone,
-			birthdate,
-			address_street,
-			address_city,
-			address_state,
-			address_zip,
-			status,
-			created_at,
-			updated_at
-		FROM proposals
+	const query = selectProposal + `
 		WHERE id = $1`
 
-	row := r.db.QueryRow(ctx, query, id)
+	row := conn(ctx, r.db).QueryRow(ctx, query, id)
 	return scanProposal(row)
 }
 
-func (r *ProposalRepository) FindByCPF(ctx context.Context, cpf string) (*entities.Proposal, error) {
-	const query = `
-		SELECT
-			id,
-			full_name,
-			cpf,
-			salary,
-			email,
-			phone,
-			birthdate,
-			address_street,
-			address_city,
-			address_state,
-			address_zip,
-			status,
-			created_at,
-			updated_at
-		FROM proposals
-		WHERE cpf = $1`
+func (r *ProposalRepository) FindLatestByCPF(ctx context.Context, cpf string) (*entities.Proposal, error) {
+	const query = selectProposal + `
+		WHERE cpf = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
 
-	row := r.db.QueryRow(ctx, query, cpf)
+	row := conn(ctx, r.db).QueryRow(ctx, query, cpf)
 	return scanProposal(row)
 }
 
+func (r *ProposalRepository) List(ctx context.Context, filter ports.ProposalFilter) ([]*entities.Proposal, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(filter.Status))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.State != "" {
+		conditions = append(conditions, "address_state = "+arg(filter.State))
+	}
+	if filter.CPFPrefix != "" {
+		conditions = append(conditions, "cpf LIKE "+arg(filter.CPFPrefix+"%"))
+	}
+	if filter.OfferExpiredAt != nil {
+		conditions = append(conditions, "offer_expires_at <= "+arg(*filter.OfferExpiredAt))
+	}
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, "updated_at < "+arg(*filter.UpdatedBefore))
+	}
+
+	sortColumn := string(ports.SortByCreatedAt)
+	if filter.SortField == ports.SortByUpdatedAt {
+		sortColumn = string(ports.SortByUpdatedAt)
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			sortColumn, comparison, arg(filter.After.SortValue), arg(filter.After.ID)))
+	}
+
+	query := selectProposal
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf("\n\t\tORDER BY %s %s, id %s\n\t\tLIMIT %s", sortColumn, direction, direction, arg(filter.Limit))
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var proposals []*entities.Proposal
+	for rows.Next() {
+		proposal, err := scanProposal(rows)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, rows.Err()
+}
+
 func scanProposal(row pgx.Row) (*entities.Proposal, error) {
 	var proposal entities.Proposal
 	var status string
+	var rejectionCode, rejectionMessage *string
+	var creditLimit, annualFee pgtype.Numeric
+	var riskTier, termsVersion, cancellationReason *string
+	var reviewCode, reviewMessage *string
+	var riskScore *int
+	var expiresAt *time.Time
+	var salary pgtype.Numeric
 
 	err := row.Scan(
 		&proposal.ID,
 		&proposal.FullName,
 		&proposal.CPF,
-		&proposal.Salary,
+		&salary,
 		&proposal.Email,
 		&proposal.Phone,
 		&proposal.BirthDate,
@@ -143,6 +303,19 @@ func scanProposal(row pgx.Row) (*entities.Proposal, error) {
 		&proposal.Address.State,
 		&proposal.Address.ZipCode,
 		&status,
+		&rejectionCode,
+		&rejectionMessage,
+		&creditLimit,
+		&riskTier,
+		&riskScore,
+		&annualFee,
+		&termsVersion,
+		&expiresAt,
+		&cancellationReason,
+		&reviewCode,
+		&reviewMessage,
+		&proposal.AnalysisRetries,
+		&proposal.Version,
 		&proposal.CreatedAt,
 		&proposal.UpdatedAt,
 	)
@@ -153,6 +326,53 @@ func scanProposal(row pgx.Row) (*entities.Proposal, error) {
 		return nil, err
 	}
 
+	if proposal.Salary, err = moneyFromNumeric(salary); err != nil {
+		return nil, fmt.Errorf("salary: %w", err)
+	}
 	proposal.Status = entities.ProposalStatus(status)
+	if cancellationReason != nil {
+		proposal.CancellationReason = *cancellationReason
+	}
+	if reviewCode != nil || reviewMessage != nil {
+		proposal.Review = &entities.ReviewReason{}
+		if reviewCode != nil {
+			proposal.Review.Code = *reviewCode
+		}
+		if reviewMessage != nil {
+			proposal.Review.Message = *reviewMessage
+		}
+	}
+	if rejectionCode != nil || rejectionMessage != nil {
+		proposal.Rejection = &entities.RejectionReason{}
+		if rejectionCode != nil {
+			proposal.Rejection.Code = *rejectionCode
+		}
+		if rejectionMessage != nil {
+			proposal.Rejection.Message = *rejectionMessage
+		}
+	}
+	if creditLimit.Valid {
+		proposal.Offer = &entities.CreditOffer{}
+		if proposal.Offer.CreditLimit, err = moneyFromNumeric(creditLimit); err != nil {
+			return nil, fmt.Errorf("credit_limit: %w", err)
+		}
+		if riskTier != nil {
+			proposal.Offer.RiskTier = *riskTier
+		}
+		if riskScore != nil {
+			proposal.Offer.RiskScore = *riskScore
+		}
+		if annualFee.Valid {
+			if proposal.Offer.AnnualFee, err = moneyFromNumeric(annualFee); err != nil {
+				return nil, fmt.Errorf("annual_fee: %w", err)
+			}
+		}
+		if termsVersion != nil {
+			proposal.Offer.TermsVersion = *termsVersion
+		}
+		if expiresAt != nil {
+			proposal.Offer.ExpiresAt = *expiresAt
+		}
+	}
 	return &proposal, nil
 }
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewDecisionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewDecisionRepository(db *pgxpool.Pool) *ReviewDecisionRepository {
+	return &ReviewDecisionRepository{db: db}
+}
+
+func (r *ReviewDecisionRepository) Save(ctx context.Context, decision *entities.ReviewDecision) error {
+	const query = `
+		INSERT INTO review_decisions (
+			id,
+			proposal_id,
+			outcome,
+			operator,
+			notes,
+			decided_at
+		) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		decision.ID,
+		decision.ProposalID,
+		decision.Outcome,
+		decision.Operator,
+		decision.Notes,
+		decision.DecidedAt,
+	)
+	return err
+}
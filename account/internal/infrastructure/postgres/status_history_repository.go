@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatusHistoryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatusHistoryRepository(db *pgxpool.Pool) *StatusHistoryRepository {
+	return &StatusHistoryRepository{db: db}
+}
+
+func (r *StatusHistoryRepository) Save(ctx context.Context, entry *entities.StatusHistoryEntry) error {
+	const query = `
+		INSERT INTO proposal_status_history (
+			id,
+			proposal_id,
+			previous_status,
+			new_status,
+			event_type,
+			message_id,
+			occurred_at
+		) VALUES ($1,$2,$3,$4,$5,NULLIF($6, ''),$7)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		entry.ID,
+		entry.ProposalID,
+		entry.PreviousStatus,
+		entry.NewStatus,
+		entry.EventType,
+		entry.MessageID,
+		entry.OccurredAt,
+	)
+	return err
+}
+
+func (r *StatusHistoryRepository) FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error) {
+	const query = `
+		SELECT
+			id,
+			proposal_id,
+			previous_status,
+			new_status,
+			event_type,
+			COALESCE(message_id, ''),
+			occurred_at
+		FROM proposal_status_history
+		WHERE proposal_id = $1
+		ORDER BY occurred_at, id`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entities.StatusHistoryEntry
+	for rows.Next() {
+		var entry entities.StatusHistoryEntry
+		var previous, current string
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.ProposalID,
+			&previous,
+			&current,
+			&entry.EventType,
+			&entry.MessageID,
+			&entry.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		entry.PreviousStatus = entities.ProposalStatus(previous)
+		entry.NewStatus = entities.ProposalStatus(current)
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
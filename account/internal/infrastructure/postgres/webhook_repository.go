@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainErrors "github.com/gabrielaraujr/golang-case/account/internal/domain"
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookSubscriptionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookSubscriptionRepository(db *pgxpool.Pool) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (r *WebhookSubscriptionRepository) Save(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	const query = `
+		INSERT INTO webhook_subscriptions (id, url, secret, created_at)
+		VALUES ($1,$2,$3,$4)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		subscription.Secret,
+		subscription.CreatedAt,
+	)
+	return err
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT id, url, secret, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1`
+
+	var s entities.WebhookSubscription
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(&s.ID, &s.URL, &s.Secret, &s.CreatedAt)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, domainErrors.ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT id, url, secret, created_at
+		FROM webhook_subscriptions
+		ORDER BY created_at`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.WebhookSubscription
+	for rows.Next() {
+		var s entities.WebhookSubscription
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &s)
+	}
+	return subscriptions, rows.Err()
+}
+
+type WebhookDeliveryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(db *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+const selectWebhookDelivery = `
+	SELECT
+		id,
+		subscription_id,
+		proposal_id,
+		event_type,
+		payload,
+		status,
+		attempts,
+		COALESCE(last_error, ''),
+		next_attempt_at,
+		created_at,
+		delivered_at
+	FROM webhook_deliveries`
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	const query = `
+		INSERT INTO webhook_deliveries (
+			id,
+			subscription_id,
+			proposal_id,
+			event_type,
+			payload,
+			status,
+			attempts,
+			next_attempt_at,
+			created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.ProposalID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	return err
+}
+
+// ClaimDue moves due deliveries to leaseUntil in a single statement, so the
+// row locks are released before any webhook is sent.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*entities.WebhookDelivery, error) {
+	const query = `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			id,
+			subscription_id,
+			proposal_id,
+			event_type,
+			payload,
+			status,
+			attempts,
+			COALESCE(last_error, ''),
+			next_attempt_at,
+			created_at,
+			delivered_at`
+
+	return r.query(ctx, query, limit, leaseUntil)
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	const query = selectWebhookDelivery + `
+		WHERE id = $1`
+
+	deliveries, err := r.query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, domainErrors.ErrWebhookDeliveryNotFound
+	}
+	return deliveries[0], nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	const query = `
+		UPDATE webhook_deliveries SET
+			status = $2,
+			attempts = $3,
+			last_error = NULLIF($4, ''),
+			next_attempt_at = $5,
+			delivered_at = $6
+		WHERE id = $1`
+
+	cmd, err := conn(ctx, r.db).Exec(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return domainErrors.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) List(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	const query = selectWebhookDelivery + `
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	return r.query(ctx, query, string(filter.Status), filter.Limit)
+}
+
+func (r *WebhookDeliveryRepository) query(ctx context.Context, query string, args ...any) ([]*entities.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		var d entities.WebhookDelivery
+		var status string
+		if err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.ProposalID,
+			&d.EventType,
+			&d.Payload,
+			&status,
+			&d.Attempts,
+			&d.LastError,
+			&d.NextAttemptAt,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		d.Status = entities.WebhookDeliveryStatus(status)
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
@@ -73,6 +73,7 @@ func (c *SQSConsumer) processMessages(ctx context.Context) {
 			log.Printf("[SQSConsumer] Error parsing message: %v", err)
 			continue
 		}
+		event.MessageID = msg.MessageId
 
 		log.Printf("[SQSConsumer] Processing message: %s", msg.MessageId)
 		if err := c.handler.Handle(ctx, &event); err != nil {
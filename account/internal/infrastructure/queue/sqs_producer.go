@@ -3,34 +3,40 @@ package queue
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-
-	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
 )
 
 type SQSConfig struct {
-	QueueURL string
+	// QueueURLs maps queue names (see ports.QueueProposals) to their URLs.
+	QueueURLs map[string]string
 }
 
 type SQSProducer struct {
-	queueURL string
+	queueURLs map[string]string
 }
 
 func NewSQSProducer(cfg SQSConfig) (*SQSProducer, error) {
-	return &SQSProducer{queueURL: cfg.QueueURL}, nil
+	return &SQSProducer{queueURLs: cfg.QueueURLs}, nil
 }
 
-func (p *SQSProducer) Publish(ctx context.Context, event *events.ProposalCreatedEvent) error {
-	body, _ := json.Marshal(event)
+func (p *SQSProducer) Publish(ctx context.Context, queue string, body []byte) error {
+	queueURL, ok := p.queueURLs[queue]
+	if !ok || queueURL == "" {
+		return fmt.Errorf("no url configured for queue %q", queue)
+	}
 
 	form := url.Values{
 		"Action":      {"SendMessage"},
 		"MessageBody": {string(body)},
 	}
 
-	req, _ := http.NewRequestWithContext(ctx, "POST", p.queueURL, bytes.NewBufferString(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", queueURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := http.DefaultClient.Do(req)
@@ -39,5 +45,11 @@ func (p *SQSProducer) Publish(ctx context.Context, event *events.ProposalCreated
 	}
 	defer resp.Body.Close()
 
+	// The outbox relay relies on this error to retry the message.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("sqs error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
 	return nil
 }
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+const maxErrorBodyBytes = 1 << 10
+
+// HTTPSender posts signed webhook payloads to partner endpoints. Any non-2xx
+// response counts as a failed delivery. Connections are only opened to
+// public addresses, checked after DNS resolution and on every redirect, so a
+// hostname that resolves to an internal address is refused.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return newHTTPSender(timeout, func(target netip.AddrPort) bool {
+		return entities.IsPublicWebhookAddr(target.Addr())
+	})
+}
+
+func newHTTPSender(timeout time.Duration, allowed func(netip.AddrPort) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			target, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("parse webhook address %q: %w", address, err)
+			}
+			if !allowed(target) {
+				return fmt.Errorf("webhook address %s is not public", target.Addr())
+			}
+			return nil
+		},
+	}
+
+	// No proxy: the dial check must see the partner's address.
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &HTTPSender{client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, request *ports.WebhookRequest) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("webhook endpoint error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/ports"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	t.Run("should refuse to connect to a non-public address", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+		}))
+		defer server.Close()
+
+		err := NewHTTPSender(time.Second).Send(context.Background(), &ports.WebhookRequest{URL: server.URL, Body: []byte(`{}`)})
+
+		if err == nil || !strings.Contains(err.Error(), "is not public") {
+			t.Errorf("expected non-public address error, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("expected no request to reach the server, got %d", calls)
+		}
+	})
+
+	t.Run("should refuse a redirect to a non-public address", func(t *testing.T) {
+		internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected redirect target not to be called")
+		}))
+		defer internal.Close()
+		partner := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusTemporaryRedirect))
+		defer partner.Close()
+
+		// Both servers listen on loopback, so only the partner's port is
+		// treated as public.
+		partnerAddr := netip.MustParseAddrPort(strings.TrimPrefix(partner.URL, "http://"))
+		sender := newHTTPSender(time.Second, func(target netip.AddrPort) bool { return target == partnerAddr })
+
+		err := sender.Send(context.Background(), &ports.WebhookRequest{URL: partner.URL, Body: []byte(`{}`)})
+
+		if err == nil || !strings.Contains(err.Error(), "is not public") {
+			t.Errorf("expected redirect to be refused, got %v", err)
+		}
+	})
+
+	t.Run("should post the payload with its headers", func(t *testing.T) {
+		var got *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+		}))
+		defer server.Close()
+		sender := newHTTPSender(time.Second, func(target netip.AddrPort) bool { return target.Addr().IsLoopback() })
+
+		err := sender.Send(context.Background(), &ports.WebhookRequest{
+			URL:     server.URL,
+			Headers: map[string]string{"X-Webhook-ID": "1"},
+			Body:    []byte(`{}`),
+		})
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got == nil || got.Header.Get("X-Webhook-ID") != "1" || got.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %+v", got)
+		}
+	})
+}
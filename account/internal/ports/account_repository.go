@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+type AccountRepository interface {
+	// NextAccountNumber reserves the next sequential account number.
+	NextAccountNumber(ctx context.Context) (int64, error)
+	Save(ctx context.Context, account *entities.CustomerAccount) error
+	FindByProposalID(ctx context.Context, proposalID uuid.UUID) (*entities.CustomerAccount, error)
+}
+
+type CardRepository interface {
+	Save(ctx context.Context, card *entities.Card) error
+	FindByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entities.Card, error)
+}
+
+// CardTokenizer replaces a PAN with a token that cannot be reversed outside
+// the vault.
+type CardTokenizer interface {
+	Tokenize(ctx context.Context, pan string) (string, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+type DocumentRepository interface {
+	Save(ctx context.Context, document *entities.Document) error
+	FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.Document, error)
+	// ExistsForOtherCPF reports whether the same file, by SHA-256, was
+	// uploaded for a proposal of another CPF.
+	ExistsForOtherCPF(ctx context.Context, sha256 string, cpf string) (bool, error)
+}
+
+// DocumentStore keeps the content of uploaded documents. Keys are paths
+// such as "proposals/<proposal id>/<document id>".
+type DocumentStore interface {
+	Put(ctx context.Context, key string, contentType string, content []byte) error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord holds the response stored for an Idempotency-Key.
+// StatusCode is zero while the original request is still in flight; until
+// then ExpiresAt is the end of the reservation lease.
+type IdempotencyRecord struct {
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+type IdempotencyRepository interface {
+	// Reserve claims record.Key. When the key is already taken and not
+	// expired, the existing record is returned instead.
+	Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// Complete stores the response and ExpiresAt. Complete and Release only
+	// touch the reservation made by record, so a request that outlived its
+	// lease cannot overwrite the one that took the key over.
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	Release(ctx context.Context, record *IdempotencyRecord) error
+	// DeleteExpired removes up to limit keys that expired before the given
+	// time and returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+// JobLock keeps a periodic job running on a single replica at a time.
+type JobLock interface {
+	// TryLock takes the lock named name without waiting. acquired is false
+	// when another replica holds it; otherwise unlock must be called once the
+	// job is done.
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+type Message struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, message *Message) error
+}
+
+type NotificationLogRepository interface {
+	Save(ctx context.Context, delivery *entities.NotificationDelivery) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+type OfferEvidenceRepository interface {
+	Save(ctx context.Context, evidence *entities.OfferEvidence) error
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+type OutboxStats struct {
+	Pending       int
+	Stuck         int
+	OldestPending *time.Time
+}
+
+type OutboxRepository interface {
+	Save(ctx context.Context, message *entities.OutboxMessage) error
+	FetchPending(ctx context.Context, limit int) ([]*entities.OutboxMessage, error)
+	Update(ctx context.Context, message *entities.OutboxMessage) error
+	Stats(ctx context.Context, stuckAttempts int) (*OutboxStats, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProposalEvent is a live update about a proposal, fanned out to every
+// account replica.
+type ProposalEvent struct {
+	ProposalID uuid.UUID `json:"proposal_id"`
+	EventType  string    `json:"event_type"`
+	Status     string    `json:"status"`
+	Final      bool      `json:"final"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type ProposalEventPublisher interface {
+	Publish(ctx context.Context, event *ProposalEvent) error
+}
+
+// ProposalEventSubscriber delivers events for one proposal until the
+// returned cancel function is called. The channel is closed early when the
+// subscriber falls behind, since events were lost; the caller should load
+// the proposal again before resubscribing.
+type ProposalEventSubscriber interface {
+	Subscribe(ctx context.Context, proposalID uuid.UUID) (<-chan *ProposalEvent, func(), error)
+}
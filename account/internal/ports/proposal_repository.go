@@ -2,14 +2,53 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
 	"github.com/google/uuid"
 )
 
+type ProposalSortField string
+
+const (
+	SortByCreatedAt ProposalSortField = "created_at"
+	SortByUpdatedAt ProposalSortField = "updated_at"
+)
+
+// ProposalCursor is the keyset position after which the next page starts.
+type ProposalCursor struct {
+	SortValue time.Time
+	ID        uuid.UUID
+}
+
+type ProposalFilter struct {
+	Status      entities.ProposalStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	State       string
+	CPFPrefix   string
+	SortField   ProposalSortField
+	Descending  bool
+	After       *ProposalCursor
+	Limit       int
+
+	// OfferExpiredAt keeps only offers whose deadline is at or before it.
+	OfferExpiredAt *time.Time
+	// UpdatedBefore keeps only proposals last changed before it.
+	UpdatedBefore *time.Time
+}
+
 type ProposalRepository interface {
+	// Save fails with ErrProposalAlreadyOpen if the CPF has another open
+	// proposal.
 	Save(ctx context.Context, proposal *entities.Proposal) error
+	// Update only succeeds if the stored version still matches
+	// proposal.Version, and increments it. Otherwise it fails with
+	// ErrProposalVersionConflict: the proposal must be read again.
 	Update(ctx context.Context, proposal *entities.Proposal) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.Proposal, error)
-	FindByCPF(ctx context.Context, cpf string) (*entities.Proposal, error)
+	// FindLatestByCPF returns the most recent proposal of a CPF, or
+	// ErrProposalNotFound if it never applied.
+	FindLatestByCPF(ctx context.Context, cpf string) (*entities.Proposal, error)
+	List(ctx context.Context, filter ProposalFilter) ([]*entities.Proposal, error)
 }
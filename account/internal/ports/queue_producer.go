@@ -2,10 +2,15 @@ package ports
 
 import (
 	"context"
+)
 
-	events "github.com/gabrielaraujr/golang-case/account/internal/domain"
+// Queue names the account service publishes to.
+const (
+	QueueProposals      = "proposals"
+	QueueProposalEvents = "proposal-events"
 )
 
+// QueueProducer sends an encoded event to the named queue.
 type QueueProducer interface {
-	Publish(ctx context.Context, event *events.ProposalCreatedEvent) error
+	Publish(ctx context.Context, queue string, body []byte) error
 }
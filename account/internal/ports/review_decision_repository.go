@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+)
+
+type ReviewDecisionRepository interface {
+	Save(ctx context.Context, decision *entities.ReviewDecision) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+type StatusHistoryRepository interface {
+	Save(ctx context.Context, entry *entities.StatusHistoryEntry) error
+	FindByProposalID(ctx context.Context, proposalID uuid.UUID) ([]*entities.StatusHistoryEntry, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// TransactionManager runs fn inside a single database transaction. Repositories
+// called with the context passed to fn take part in that transaction.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielaraujr/golang-case/account/internal/domain/entities"
+	"github.com/google/uuid"
+)
+
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, subscription *entities.WebhookSubscription) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	List(ctx context.Context) ([]*entities.WebhookSubscription, error)
+}
+
+type WebhookDeliveryFilter struct {
+	Status entities.WebhookDeliveryStatus
+	Limit  int
+}
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery *entities.WebhookDelivery) error
+	// ClaimDue reschedules up to limit due deliveries to leaseUntil and
+	// returns them, so other dispatchers skip them while they are sent.
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*entities.WebhookDelivery, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+	List(ctx context.Context, filter WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+}
+
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, request *WebhookRequest) error
+}
@@ -5,4 +5,5 @@ set -e
 printf "\n\nCreating SQS queues...\n"
 awslocal sqs create-queue --queue-name proposals
 awslocal sqs create-queue --queue-name risk-results
+awslocal sqs create-queue --queue-name proposal-events
 printf "\n\nSQS queues created successfully!\n"
@@ -9,6 +9,8 @@ printf "\n\nCreating database account_proposals...\n"
 psql -U postgres -tc "${SELECT}" | grep -q 1 || psql -U postgres -c "${CREATE}"
 
 printf "\n\nRunning migrations...\n"
-psql -U postgres -d account_proposals -f /migrations/001_create_proposals_table.sql
+for migration in /migrations/*.sql; do
+    psql -U postgres -d account_proposals -v ON_ERROR_STOP=1 -f "${migration}"
+done
 
 printf "\n\nDatabase setup completed.\n"
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id UUID PRIMARY KEY,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages(next_attempt_at) WHERE sent_at IS NULL;
@@ -0,0 +1,3 @@
+-- Keyset pagination for GET /proposals. Status filtering keeps using idx_proposals_status.
+CREATE INDEX IF NOT EXISTS idx_proposals_created_at_id ON proposals(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_proposals_updated_at_id ON proposals(updated_at, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS proposal_status_history (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    previous_status VARCHAR(50) NOT NULL,
+    new_status VARCHAR(50) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    message_id VARCHAR(255),
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_proposal_status_history_proposal ON proposal_status_history(proposal_id, occurred_at);
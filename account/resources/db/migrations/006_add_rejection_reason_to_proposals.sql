@@ -0,0 +1,3 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS rejection_code VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS rejection_message TEXT;
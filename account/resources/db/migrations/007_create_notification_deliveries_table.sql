@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    channel VARCHAR(20) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    template VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_notification_deliveries_proposal ON notification_deliveries(proposal_id, created_at);
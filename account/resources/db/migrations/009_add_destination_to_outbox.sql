@@ -0,0 +1 @@
+ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS destination VARCHAR(100) NOT NULL DEFAULT 'proposals';
@@ -0,0 +1,25 @@
+CREATE SEQUENCE IF NOT EXISTS customer_account_number_seq START 1;
+
+CREATE TABLE IF NOT EXISTS customer_accounts (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL UNIQUE REFERENCES proposals(id),
+    branch VARCHAR(4) NOT NULL,
+    number VARCHAR(12) NOT NULL,
+    check_digit CHAR(1) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_branch_number UNIQUE (branch, number)
+);
+
+CREATE TABLE IF NOT EXISTS cards (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES customer_accounts(id),
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    token VARCHAR(100) NOT NULL UNIQUE,
+    masked_pan VARCHAR(19) NOT NULL,
+    expiry_month SMALLINT NOT NULL,
+    expiry_year SMALLINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_cards_account ON cards(account_id);
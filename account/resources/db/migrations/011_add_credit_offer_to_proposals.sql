@@ -0,0 +1,4 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS offer_credit_limit DECIMAL(12, 2),
+    ADD COLUMN IF NOT EXISTS offer_risk_tier VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS offer_risk_score INTEGER;
@@ -0,0 +1,22 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS offer_annual_fee DECIMAL(10, 2),
+    ADD COLUMN IF NOT EXISTS offer_terms_version VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS offer_expires_at TIMESTAMP;
+
+-- Proposals approved before the acceptance step already had their account opened.
+UPDATE proposals SET status = 'accepted' WHERE status = 'approved';
+
+CREATE INDEX IF NOT EXISTS idx_proposals_offer_expiry ON proposals(offer_expires_at)
+    WHERE status = 'offer_pending';
+
+CREATE TABLE IF NOT EXISTS offer_evidences (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    decision VARCHAR(20) NOT NULL,
+    terms_version VARCHAR(50),
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL,
+    decided_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_offer_evidences_proposal ON offer_evidences(proposal_id);
@@ -0,0 +1,9 @@
+-- A CPF may have many proposals over time, but only one open at a time.
+-- The statuses below are the ones Proposal.IsFinalized treats as open.
+ALTER TABLE proposals DROP CONSTRAINT IF EXISTS unique_cpf;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_proposals_open_cpf ON proposals(cpf)
+    WHERE status IN ('pending', 'analyzing', 'offer_pending');
+
+CREATE INDEX IF NOT EXISTS idx_proposals_cpf_created_at ON proposals(cpf, created_at DESC);
+DROP INDEX IF EXISTS idx_proposals_cpf;
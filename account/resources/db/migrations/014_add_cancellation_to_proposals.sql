@@ -0,0 +1,2 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
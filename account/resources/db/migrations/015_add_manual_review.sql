@@ -0,0 +1,22 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS review_code VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS review_message TEXT;
+
+-- Proposals under review are open: the CPF cannot apply again meanwhile.
+DROP INDEX IF EXISTS uq_proposals_open_cpf;
+CREATE UNIQUE INDEX uq_proposals_open_cpf ON proposals(cpf)
+    WHERE status IN ('pending', 'analyzing', 'under_review', 'offer_pending');
+
+CREATE INDEX IF NOT EXISTS idx_proposals_review_queue ON proposals(created_at, id)
+    WHERE status = 'under_review';
+
+CREATE TABLE IF NOT EXISTS review_decisions (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    outcome VARCHAR(20) NOT NULL,
+    operator VARCHAR(255) NOT NULL,
+    notes TEXT NOT NULL,
+    decided_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_review_decisions_proposal ON review_decisions(proposal_id);
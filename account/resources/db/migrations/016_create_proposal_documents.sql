@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS proposal_documents (
+    id UUID PRIMARY KEY,
+    proposal_id UUID NOT NULL REFERENCES proposals(id),
+    type VARCHAR(30) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_proposal_documents_proposal ON proposal_documents(proposal_id);
+CREATE INDEX idx_proposal_documents_sha256 ON proposal_documents(sha256);
@@ -0,0 +1,4 @@
+ALTER TABLE proposal_documents
+    ADD COLUMN IF NOT EXISTS width INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS height INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS page_count INT NOT NULL DEFAULT 0;
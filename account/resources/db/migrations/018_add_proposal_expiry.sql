@@ -0,0 +1,6 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS analysis_retries INT NOT NULL DEFAULT 0;
+
+-- Proposals waiting on risk-analysis, scanned by StuckProposalDetector.
+CREATE INDEX IF NOT EXISTS idx_proposals_stuck ON proposals(status, updated_at)
+    WHERE status IN ('pending', 'analyzing');
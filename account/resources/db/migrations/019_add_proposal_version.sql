@@ -0,0 +1,3 @@
+-- Optimistic lock: every update checks and increments the version it read.
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- ip_address is the connecting address; the forwarded header is kept apart,
+-- and only when the request came through a trusted proxy.
+ALTER TABLE offer_evidences
+    ADD COLUMN IF NOT EXISTS forwarded_for TEXT;
@@ -33,45 +33,60 @@ func (s *AnalyzeProposalService) Handle(ctx context.Context, event *domain.Propo
 
 	// Document analysis
 	documentResult := domain.AnalyzeDocuments(payload)
-	documentApproved := documentResult.Approved
-	if !documentApproved {
+	if !documentResult.Approved {
 		s.logger.Warn(ctx, "[RiskAnalysis] Documents rejected", "proposal_id", proposalID, "reason", documentResult.Reason)
-		return s.publish(ctx, domain.EventDocumentsRejected, proposalID, documentApproved)
+		return s.publish(ctx, domain.EventDocumentsRejected, proposalID, documentResult)
 	}
 
 	s.logger.Info(ctx, "[RiskAnalysis] Documents approved", "proposal_id", proposalID)
-	if err := s.publish(ctx, domain.EventDocumentsApproved, proposalID, documentApproved); err != nil {
+	if err := s.publish(ctx, domain.EventDocumentsApproved, proposalID, documentResult); err != nil {
 		return err
 	}
 
 	// Credit analysis
 	creditResult := domain.AnalyzeCredit(payload)
-	if !creditResult.Approved {
+	if !creditResult.Approved && !creditResult.NeedsReview {
 		s.logger.Warn(ctx, "[RiskAnalysis] Credit rejected", "proposal_id", proposalID, "reason", creditResult.Reason)
-		return s.publish(ctx, domain.EventCreditRejected, proposalID, creditResult.Approved)
+		return s.publish(ctx, domain.EventCreditRejected, proposalID, creditResult)
 	}
 
 	// Fraud analysis
 	fraudResult := domain.AnalyzeFraud(payload)
 	if !fraudResult.Approved {
 		s.logger.Warn(ctx, "[RiskAnalysis] Fraud rejected", "proposal_id", proposalID, "reason", fraudResult.Reason)
-		return s.publish(ctx, domain.EventFraudRejected, proposalID, fraudResult.Approved)
+		return s.publish(ctx, domain.EventFraudRejected, proposalID, fraudResult)
 	}
 
-	// All analyses passed
-	s.logger.Info(ctx, "[RiskAnalysis] Proposal fully approved", "proposal_id", proposalID)
-	return s.publish(ctx, domain.EventRiskAnalysisCompleted, proposalID, true)
+	// A borderline credit result is only sent to review once fraud passed
+	if creditResult.NeedsReview {
+		s.logger.Info(ctx, "[RiskAnalysis] Proposal sent to manual review", "proposal_id", proposalID, "reason", creditResult.Reason)
+		return s.publish(ctx, domain.EventManualReviewRequired, proposalID, creditResult)
+	}
+
+	// All analyses passed, the credit result carries the offer
+	s.logger.Info(ctx, "[RiskAnalysis] Proposal fully approved", "proposal_id", proposalID,
+		"credit_limit", creditResult.Offer.CreditLimit, "risk_tier", creditResult.Offer.RiskTier)
+	return s.publish(ctx, domain.EventRiskAnalysisCompleted, proposalID, creditResult)
 }
 
 func (s *AnalyzeProposalService) publish(
 	ctx context.Context,
 	eventType string,
 	proposalID uuid.UUID,
-	approved bool,
+	result domain.AnalysisResult,
 ) error {
-	return s.producer.Publish(ctx, &domain.ProposalStatusChangedEvent{
-		EventType:  eventType,
-		ProposalID: proposalID,
-		Approved:   approved,
-	})
+	event := &domain.ProposalStatusChangedEvent{
+		EventType:     eventType,
+		ProposalID:    proposalID,
+		Approved:      result.Approved,
+		ReasonCode:    result.Code,
+		ReasonMessage: result.Reason,
+	}
+	if result.Offer != nil {
+		event.CreditLimit = result.Offer.CreditLimit
+		event.CreditLimitCents = result.Offer.CreditLimit.Cents()
+		event.RiskTier = string(result.Offer.RiskTier)
+		event.RiskScore = result.Offer.RiskScore
+	}
+	return s.producer.Publish(ctx, event)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	events "github.com/gabrielaraujr/golang-case/risk-analysis/internal/domain"
@@ -49,6 +50,14 @@ func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any) {
 	m.warnCalls++
 }
 
+// validDocuments returns documents that pass every document rule.
+func validDocuments() []events.DocumentMetadata {
+	return []events.DocumentMetadata{
+		{Type: events.DocumentIdentity, ContentType: "image/png", Width: 800, Height: 600},
+		{Type: events.DocumentProofOfIncome, ContentType: "application/pdf", Pages: 1},
+	}
+}
+
 func assertEventCount(t *testing.T, events []*events.ProposalStatusChangedEvent, expected int) {
 	t.Helper()
 	if len(events) != expected {
@@ -82,34 +91,68 @@ func TestAnalyzeProposalServiceHandle(t *testing.T) {
 		wantEvents     int
 		wantEventTypes []string
 		wantApproved   []bool
+		wantReasonCode string
+		wantLimit      events.Money
+		wantTier       string
 	}{
 		{
 			name:           "documents rejection",
-			payload:        &events.ProposalPayload{CPF: "123", FullName: "John Doe", Salary: 5000.0},
+			payload:        &events.ProposalPayload{CPF: "123", FullName: "John Doe", Salary: events.MustParseMoney("5000.0")},
+			wantEvents:     1,
+			wantEventTypes: []string{events.EventDocumentsRejected},
+			wantApproved:   []bool{false},
+			wantReasonCode: events.ReasonCPFInvalidLength,
+		},
+		{
+			name:           "missing documents rejection",
+			payload:        &events.ProposalPayload{CPF: "12345678224", FullName: "John Doe", Salary: events.MustParseMoney("5000.0"), Documents: validDocuments()[1:]},
 			wantEvents:     1,
 			wantEventTypes: []string{events.EventDocumentsRejected},
 			wantApproved:   []bool{false},
+			wantReasonCode: events.ReasonDocumentMissing,
 		},
 		{
 			name:           "credit rejection",
-			payload:        &events.ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: 2000.0},
+			payload:        &events.ProposalPayload{CPF: "12345678224", FullName: "John Doe", Salary: events.MustParseMoney("2000.0"), Documents: validDocuments()},
 			wantEvents:     2,
 			wantEventTypes: []string{events.EventDocumentsApproved, events.EventCreditRejected},
 			wantApproved:   []bool{true, false},
+			wantReasonCode: events.ReasonSalaryBelowMinimum,
+		},
+		{
+			name:           "borderline credit goes to manual review",
+			payload:        &events.ProposalPayload{CPF: "12345678224", FullName: "John Doe", Salary: events.MustParseMoney("2900.00"), Documents: validDocuments()},
+			wantEvents:     2,
+			wantEventTypes: []string{events.EventDocumentsApproved, events.EventManualReviewRequired},
+			wantApproved:   []bool{true, false},
+			wantReasonCode: events.ReasonSalaryBorderline,
+			wantLimit:      events.MustParseMoney("1700"),
+			wantTier:       string(events.RiskTierHigh),
+		},
+		{
+			name:           "borderline credit with fraud is rejected",
+			payload:        &events.ProposalPayload{CPF: "12345678909", FullName: "John Doe", Salary: events.MustParseMoney("2900.00"), Documents: validDocuments()},
+			wantEvents:     2,
+			wantEventTypes: []string{events.EventDocumentsApproved, events.EventFraudRejected},
+			wantApproved:   []bool{true, false},
+			wantReasonCode: events.ReasonFraudSuspected,
 		},
 		{
 			name:           "fraud rejection",
-			payload:        &events.ProposalPayload{CPF: "12345678901", FullName: "John Doe", Salary: 5000.0},
+			payload:        &events.ProposalPayload{CPF: "12345678909", FullName: "John Doe", Salary: events.MustParseMoney("5000.0"), Documents: validDocuments()},
 			wantEvents:     2,
 			wantEventTypes: []string{events.EventDocumentsApproved, events.EventFraudRejected},
 			wantApproved:   []bool{true, false},
+			wantReasonCode: events.ReasonFraudSuspected,
 		},
 		{
 			name:           "all approved",
-			payload:        &events.ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: 5000.0},
+			payload:        &events.ProposalPayload{CPF: "12345678224", FullName: "John Doe", Salary: events.MustParseMoney("5000.0"), Documents: validDocuments()},
 			wantEvents:     2,
 			wantEventTypes: []string{events.EventDocumentsApproved, events.EventRiskAnalysisCompleted},
 			wantApproved:   []bool{true, true},
+			wantLimit:      events.MustParseMoney("3000"),
+			wantTier:       string(events.RiskTierHigh),
 		},
 	}
 
@@ -133,6 +176,23 @@ func TestAnalyzeProposalServiceHandle(t *testing.T) {
 			for i := 0; i < tt.wantEvents; i++ {
 				assertEvent(t, queueProducer.published[i], tt.wantEventTypes[i], tt.wantApproved[i], proposalID)
 			}
+
+			last := queueProducer.published[tt.wantEvents-1]
+			if last.ReasonCode != tt.wantReasonCode {
+				t.Errorf("event.ReasonCode = %q, want %q", last.ReasonCode, tt.wantReasonCode)
+			}
+			if (last.ReasonMessage != "") != (tt.wantReasonCode != "") {
+				t.Errorf("event.ReasonMessage = %q, want message only with a reason code", last.ReasonMessage)
+			}
+			if last.CreditLimit != tt.wantLimit {
+				t.Errorf("event.CreditLimit = %s, want %s", last.CreditLimit, tt.wantLimit)
+			}
+			if last.CreditLimitCents != tt.wantLimit.Cents() {
+				t.Errorf("event.CreditLimitCents = %d, want %d", last.CreditLimitCents, tt.wantLimit.Cents())
+			}
+			if last.RiskTier != tt.wantTier {
+				t.Errorf("event.RiskTier = %q, want %q", last.RiskTier, tt.wantTier)
+			}
 		})
 	}
 }
@@ -152,8 +212,8 @@ func TestAnalyzeProposalServiceHandleValidationErrors(t *testing.T) {
 			name: "empty full name",
 			payload: &events.ProposalPayload{
 				FullName: "",
-				CPF:      "12345678902",
-				Salary:   5000.0,
+				CPF:      "12345678224",
+				Salary:   events.MustParseMoney("5000.0"),
 			},
 			wantErr: events.ErrEmptyFullName,
 		},
@@ -162,7 +222,7 @@ func TestAnalyzeProposalServiceHandleValidationErrors(t *testing.T) {
 			payload: &events.ProposalPayload{
 				FullName: "John Doe",
 				CPF:      "",
-				Salary:   5000.0,
+				Salary:   events.MustParseMoney("5000.0"),
 			},
 			wantErr: events.ErrEmptyCPF,
 		},
@@ -170,8 +230,8 @@ func TestAnalyzeProposalServiceHandleValidationErrors(t *testing.T) {
 			name: "negative salary",
 			payload: &events.ProposalPayload{
 				FullName: "John Doe",
-				CPF:      "12345678902",
-				Salary:   -100.0,
+				CPF:      "12345678224",
+				Salary:   events.MustParseMoney("-100.0"),
 			},
 			wantErr: events.ErrNegativeSalary,
 		},
@@ -198,3 +258,27 @@ func TestAnalyzeProposalServiceHandleValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+// With the default account config the analysis is requested when the
+// proposal is created, so the first event carries no documents.
+func TestAnalyzeProposalServiceHandleProposalWithoutDocumentsYet(t *testing.T) {
+	data := `{
+		"event_type": "ProposalCreated",
+		"proposal_id": "6f1c3a52-5a5e-4f55-9f0a-0c8d1f6d7b11",
+		"payload": {"full_name": "John Doe", "cpf": "12345678224", "salary": 5000, "salary_cents": 500000, "birth_date": "1990-05-10"}
+	}`
+	var event events.ProposalCreatedEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	queueProducer := newMockQueueProducer()
+	service := NewAnalyzeProposalService(queueProducer, newMockLogger())
+
+	if err := service.Handle(context.Background(), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertEventCount(t, queueProducer.published, 2)
+	assertEvent(t, queueProducer.published[0], events.EventDocumentsApproved, true, event.ProposalID)
+	assertEvent(t, queueProducer.published[1], events.EventRiskAnalysisCompleted, true, event.ProposalID)
+}
@@ -1,45 +1,105 @@
 package domain
 
+import "errors"
+
+// Machine-readable rejection reason codes sent to the account service.
+const (
+	ReasonCPFInvalidLength     = "CPF_INVALID_LENGTH"
+	ReasonCPFInvalidFormat     = "CPF_INVALID_FORMAT"
+	ReasonCPFRepeatedDigits    = "CPF_REPEATED_DIGITS"
+	ReasonCPFInvalidCheckDigit = "CPF_INVALID_CHECK_DIGIT"
+	ReasonFullNameTooShort     = "FULL_NAME_TOO_SHORT"
+	ReasonDocumentMissing      = "DOCUMENT_MISSING"
+	ReasonDocumentReused       = "DOCUMENT_REUSED"
+	ReasonImageResolutionLow   = "IMAGE_RESOLUTION_TOO_LOW"
+	ReasonPDFPageCountInvalid  = "PDF_PAGE_COUNT_INVALID"
+	ReasonSalaryBelowMinimum   = "SALARY_BELOW_MINIMUM"
+	ReasonSalaryBorderline     = "SALARY_BORDERLINE"
+	ReasonFraudSuspected       = "FRAUD_SUSPECTED"
+)
+
 type AnalysisResult struct {
 	Approved bool
+	Code     string
 	Reason   string
+
+	// NeedsReview marks a borderline case that an operator decides. Such a
+	// result is not approved, but it is not a rejection either.
+	NeedsReview bool
+
+	// Offer is set by an approved credit analysis, and by one sent to review
+	// so the operator can approve it.
+	Offer *CreditOffer
 }
 
 func NewApproved() AnalysisResult {
-	return AnalysisResult{Approved: true, Reason: ""}
+	return AnalysisResult{Approved: true}
 }
 
-func NewRejected(reason string) AnalysisResult {
-	return AnalysisResult{Approved: false, Reason: reason}
+func NewApprovedWithOffer(offer CreditOffer) AnalysisResult {
+	return AnalysisResult{Approved: true, Offer: &offer}
+}
+
+func NewRejected(code, reason string) AnalysisResult {
+	return AnalysisResult{Approved: false, Code: code, Reason: reason}
+}
+
+func NewManualReview(code, reason string, offer CreditOffer) AnalysisResult {
+	return AnalysisResult{Approved: false, NeedsReview: true, Code: code, Reason: reason, Offer: &offer}
+}
+
+// cpfReasons maps CPF validation errors to their rejection reason code.
+var cpfReasons = []struct {
+	err  error
+	code string
+}{
+	{err: ErrCPFInvalidFormat, code: ReasonCPFInvalidFormat},
+	{err: ErrCPFInvalidLength, code: ReasonCPFInvalidLength},
+	{err: ErrCPFRepeatedDigits, code: ReasonCPFRepeatedDigits},
+	{err: ErrCPFInvalidCheckDigits, code: ReasonCPFInvalidCheckDigit},
 }
 
 func AnalyzeDocuments(payload *ProposalPayload) AnalysisResult {
-	if len(payload.CPF) != 11 {
-		return NewRejected("CPF must have exactly 11 digits")
+	if _, err := ParseCPF(payload.CPF); err != nil {
+		code := ReasonCPFInvalidLength
+		for _, r := range cpfReasons {
+			if errors.Is(err, r.err) {
+				code = r.code
+				break
+			}
+		}
+		return NewRejected(code, err.Error())
 	}
 
 	if len(payload.FullName) < 3 {
-		return NewRejected("full name must have at least 3 characters")
+		return NewRejected(ReasonFullNameTooShort, "full name must have at least 3 characters")
 	}
 
-	return NewApproved()
+	return analyzeUploadedDocuments(payload.Documents)
 }
 
+// AnalyzeCredit approves salaries above 3000. Salaries from 2700 up to 3000
+// are borderline and go to manual review.
 func AnalyzeCredit(payload *ProposalPayload) AnalysisResult {
-	const minSalary = 3000.0
+	minSalary := MoneyFromCents(300000)
+	reviewSalary := MoneyFromCents(270000)
 
+	if payload.Salary <= minSalary && payload.Salary >= reviewSalary {
+		return NewManualReview(ReasonSalaryBorderline, "salary is just under the minimum of 3000", ComputeOffer(payload))
+	}
 	if payload.Salary <= minSalary {
-		return NewRejected("salary must be greater than 3000")
+		return NewRejected(ReasonSalaryBelowMinimum, "salary must be greater than 3000")
 	}
 
-	return NewApproved()
+	return NewApprovedWithOffer(ComputeOffer(payload))
 }
 
 func AnalyzeFraud(payload *ProposalPayload) AnalysisResult {
-	lastDigit := payload.CPF[len(payload.CPF)-1] - '0'
+	cpf := cpfPunctuation.Replace(payload.CPF)
+	lastDigit := cpf[len(cpf)-1] - '0'
 
 	if lastDigit%2 != 0 {
-		return NewRejected("CPF failed fraud check")
+		return NewRejected(ReasonFraudSuspected, "CPF failed fraud check")
 	}
 
 	return NewApproved()
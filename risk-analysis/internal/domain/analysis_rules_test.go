@@ -8,54 +8,72 @@ func TestAnalyzeDocuments(t *testing.T) {
 		cpf      string
 		fullName string
 		want     bool
+		wantCode string
 	}{
-		{name: "valid documents", cpf: "12345678902", fullName: "John Doe", want: true},
-		{name: "minimal valid name", cpf: "12345678901", fullName: "Joe", want: true},
-		{name: "invalid CPF too short", cpf: "123456789", fullName: "John Doe", want: false},
-		{name: "invalid CPF too long", cpf: "123456789012", fullName: "John Doe", want: false},
-		{name: "invalid name too short", cpf: "12345678902", fullName: "Jo", want: false},
-		{name: "empty name", cpf: "12345678902", fullName: "", want: false},
+		{name: "valid documents", cpf: "12345678224", fullName: "John Doe", want: true},
+		{name: "minimal valid name", cpf: "12345678909", fullName: "Joe", want: true},
+		{name: "invalid CPF too short", cpf: "123456789", fullName: "John Doe", want: false, wantCode: ReasonCPFInvalidLength},
+		{name: "invalid CPF too long", cpf: "123456789012", fullName: "John Doe", want: false, wantCode: ReasonCPFInvalidLength},
+		{name: "formatted CPF", cpf: "123.456.782-24", fullName: "John Doe", want: true},
+		{name: "CPF with letters", cpf: "1234567822a", fullName: "John Doe", want: false, wantCode: ReasonCPFInvalidFormat},
+		{name: "repeated digits CPF", cpf: "11111111111", fullName: "John Doe", want: false, wantCode: ReasonCPFRepeatedDigits},
+		{name: "invalid check digit", cpf: "12345678901", fullName: "John Doe", want: false, wantCode: ReasonCPFInvalidCheckDigit},
+		{name: "invalid name too short", cpf: "12345678224", fullName: "Jo", want: false, wantCode: ReasonFullNameTooShort},
+		{name: "empty name", cpf: "12345678224", fullName: "", want: false, wantCode: ReasonFullNameTooShort},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			payload := &ProposalPayload{
-				CPF:      tt.cpf,
-				FullName: tt.fullName,
-				Salary:   5000.0,
+				CPF:       tt.cpf,
+				FullName:  tt.fullName,
+				Salary:    MustParseMoney("5000.0"),
+				Documents: validDocuments(),
 			}
 			result := AnalyzeDocuments(payload)
 			if result.Approved != tt.want {
 				t.Errorf("AnalyzeDocuments(%q, %q) = %v, want %v", tt.cpf, tt.fullName, result.Approved, tt.want)
 			}
+			if result.Code != tt.wantCode {
+				t.Errorf("AnalyzeDocuments(%q, %q).Code = %q, want %q", tt.cpf, tt.fullName, result.Code, tt.wantCode)
+			}
 		})
 	}
 }
 
 func TestAnalyzeCredit(t *testing.T) {
 	tests := []struct {
-		name   string
-		salary float64
-		want   bool
+		name       string
+		salary     Money
+		want       bool
+		wantReview bool
 	}{
-		{name: "above threshold", salary: 5000.0, want: true},
-		{name: "just above threshold", salary: 3000.01, want: true},
-		{name: "at threshold", salary: 3000.0, want: false},
-		{name: "below threshold", salary: 2999.99, want: false},
-		{name: "zero", salary: 0.0, want: false},
-		{name: "negative", salary: -100.0, want: false},
+		{name: "above threshold", salary: MustParseMoney("5000.0"), want: true},
+		{name: "just above threshold", salary: MustParseMoney("3000.01"), want: true},
+		{name: "at threshold", salary: MustParseMoney("3000.0"), wantReview: true},
+		{name: "just below threshold", salary: MustParseMoney("2999.99"), wantReview: true},
+		{name: "at review floor", salary: MustParseMoney("2700.00"), wantReview: true},
+		{name: "below review floor", salary: MustParseMoney("2699.99"), want: false},
+		{name: "zero", salary: MustParseMoney("0.0"), want: false},
+		{name: "negative", salary: MustParseMoney("-100.0"), want: false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			payload := &ProposalPayload{
-				CPF:      "12345678902",
+				CPF:      "12345678224",
 				FullName: "John Doe",
 				Salary:   tt.salary,
 			}
 			result := AnalyzeCredit(payload)
 			if result.Approved != tt.want {
-				t.Errorf("AnalyzeCredit(salary=%.2f) = %v, want %v", tt.salary, result.Approved, tt.want)
+				t.Errorf("AnalyzeCredit(salary=%s) = %v, want %v", tt.salary, result.Approved, tt.want)
+			}
+			if result.NeedsReview != tt.wantReview {
+				t.Errorf("AnalyzeCredit(salary=%s) review = %v, want %v", tt.salary, result.NeedsReview, tt.wantReview)
+			}
+			if tt.wantReview && (result.Code != ReasonSalaryBorderline || result.Offer == nil) {
+				t.Errorf("expected %s with an offer, got %+v", ReasonSalaryBorderline, result)
 			}
 		})
 	}
@@ -84,7 +102,7 @@ func TestAnalyzeFraud(t *testing.T) {
 			payload := &ProposalPayload{
 				CPF:      tt.cpf,
 				FullName: "John Doe",
-				Salary:   5000.0,
+				Salary:   MustParseMoney("5000.0"),
 			}
 			result := AnalyzeFraud(payload)
 			if result.Approved != tt.want {
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const cpfLength = 11
+
+// CPF is a validated Brazilian taxpayer number, kept as its 11 digits.
+// The same rules are implemented in account/internal/domain/entities/cpf.go.
+type CPF string
+
+var (
+	ErrCPFInvalidFormat      = errors.New("CPF must contain only digits, dots and a dash")
+	ErrCPFInvalidLength      = errors.New("CPF must have exactly 11 digits")
+	ErrCPFRepeatedDigits     = errors.New("CPF cannot be a sequence of repeated digits")
+	ErrCPFInvalidCheckDigits = errors.New("CPF check digits are invalid")
+)
+
+var cpfPunctuation = strings.NewReplacer(".", "", "-", "", " ", "")
+
+// ParseCPF accepts a CPF with or without punctuation ("123.456.789-09") and
+// checks its length, repeated digit sequences and both check digits.
+func ParseCPF(raw string) (CPF, error) {
+	digits := cpfPunctuation.Replace(strings.TrimSpace(raw))
+	if digits == "" {
+		return "", ErrEmptyCPF
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrCPFInvalidFormat
+		}
+	}
+	if len(digits) != cpfLength {
+		return "", ErrCPFInvalidLength
+	}
+	if strings.Count(digits, digits[:1]) == cpfLength {
+		return "", ErrCPFRepeatedDigits
+	}
+
+	first := cpfCheckDigit(digits[:9])
+	second := cpfCheckDigit(digits[:9] + string(first))
+	if digits[9] != first || digits[10] != second {
+		return "", ErrCPFInvalidCheckDigits
+	}
+	return CPF(digits), nil
+}
+
+func (c CPF) String() string {
+	return string(c)
+}
+
+// cpfCheckDigit computes the modulo 11 check digit of digits, weighting
+// them from len+1 down to 2.
+func cpfCheckDigit(digits string) byte {
+	sum := 0
+	weight := len(digits) + 1
+	for i := range len(digits) {
+		sum += int(digits[i]-'0') * weight
+		weight--
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCPF(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CPF
+		wantErr error
+	}{
+		{name: "digits only", input: "12345678909", want: "12345678909"},
+		{name: "formatted", input: "123.456.789-09", want: "12345678909"},
+		{name: "check digit zero", input: "98765432100", want: "98765432100"},
+		{name: "empty", input: "", wantErr: ErrEmptyCPF},
+		{name: "letters", input: "1234567890a", wantErr: ErrCPFInvalidFormat},
+		{name: "too short", input: "123456789", wantErr: ErrCPFInvalidLength},
+		{name: "repeated digits", input: "99999999999", wantErr: ErrCPFRepeatedDigits},
+		{name: "wrong first check digit", input: "12345678919", wantErr: ErrCPFInvalidCheckDigits},
+		{name: "wrong second check digit", input: "12345678901", wantErr: ErrCPFInvalidCheckDigits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCPF(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCPF(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCPF(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
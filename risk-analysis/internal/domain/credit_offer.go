@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RiskTier classifies an approved proposal by its risk score.
+type RiskTier string
+
+const (
+	RiskTierLow    RiskTier = "LOW"
+	RiskTierMedium RiskTier = "MEDIUM"
+	RiskTierHigh   RiskTier = "HIGH"
+)
+
+// Limits are in centavos.
+const (
+	maxRiskScore    = 1000
+	maxCreditLimit  = 10000000
+	creditLimitStep = 10000
+)
+
+// CreditOffer is the limit granted to an approved proposal, sent to the
+// account service with RiskAnalysisCompleted.
+type CreditOffer struct {
+	CreditLimit Money
+	RiskTier    RiskTier
+	RiskScore   int
+}
+
+// salaryBands maps monthly salary ranges to the limit multiplier applied
+// before the tier adjustment. Bands are checked in order.
+var salaryBands = []struct {
+	upTo       Money
+	multiplier float64
+}{
+	{upTo: MoneyFromCents(500000), multiplier: 1.0},
+	{upTo: MoneyFromCents(1000000), multiplier: 1.5},
+	{upTo: MoneyFromCents(2000000), multiplier: 2.0},
+	{upTo: MoneyFromCents(math.MaxInt64), multiplier: 3.0},
+}
+
+var tierFactors = map[RiskTier]float64{
+	RiskTierLow:    1.0,
+	RiskTierMedium: 0.8,
+	RiskTierHigh:   0.6,
+}
+
+// AgeBand groups applicants by age for the credit limit.
+type AgeBand string
+
+const (
+	AgeBandUnknown    AgeBand = ""
+	AgeBandYoungAdult AgeBand = "18-24"
+	AgeBandAdult      AgeBand = "25-59"
+	AgeBandSenior     AgeBand = "60+"
+)
+
+// ageBandFactors adjust the limit after the tier factor. Applicants without
+// a birth date keep the full limit.
+var ageBandFactors = map[AgeBand]float64{
+	AgeBandUnknown:    1.0,
+	AgeBandYoungAdult: 0.8,
+	AgeBandAdult:      1.0,
+	AgeBandSenior:     0.9,
+}
+
+// AgeBandFor classifies the applicant by age on the date of now.
+func AgeBandFor(payload *ProposalPayload, now time.Time) AgeBand {
+	birthDate, err := time.Parse(time.DateOnly, payload.BirthDate)
+	if err != nil {
+		return AgeBandUnknown
+	}
+
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	switch {
+	case age < 25:
+		return AgeBandYoungAdult
+	case age < 60:
+		return AgeBandAdult
+	default:
+		return AgeBandSenior
+	}
+}
+
+// RiskScore scores a proposal from 0 to 1000, higher meaning lower risk.
+func RiskScore(payload *ProposalPayload) int {
+	score := 300 + int(payload.Salary.Cents()/5000)
+	return max(0, min(score, maxRiskScore))
+}
+
+func TierFor(score int) RiskTier {
+	switch {
+	case score >= 700:
+		return RiskTierLow
+	case score >= 450:
+		return RiskTierMedium
+	default:
+		return RiskTierHigh
+	}
+}
+
+// ComputeOffer derives the credit limit from the salary band, the risk tier
+// and the age band. The limit is rounded down to the nearest R$ 100,00 and
+// capped at R$ 100.000,00.
+func ComputeOffer(payload *ProposalPayload) CreditOffer {
+	score := RiskScore(payload)
+	tier := TierFor(score)
+
+	multiplier := salaryBands[len(salaryBands)-1].multiplier
+	for _, band := range salaryBands {
+		if payload.Salary <= band.upTo {
+			multiplier = band.multiplier
+			break
+		}
+	}
+
+	// The factors are floats, so the product is rounded to the centavo
+	// before flooring; otherwise 2999.9999 would floor to 2900.
+	factor := multiplier * tierFactors[tier] * ageBandFactors[AgeBandFor(payload, time.Now())]
+	limit := int64(math.Round(float64(payload.Salary.Cents()) * factor))
+	limit = min(limit/creditLimitStep*creditLimitStep, maxCreditLimit)
+
+	return CreditOffer{
+		CreditLimit: MoneyFromCents(limit),
+		RiskTier:    tier,
+		RiskScore:   score,
+	}
+}
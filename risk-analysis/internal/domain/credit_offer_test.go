@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeOffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		salary    Money
+		wantScore int
+		wantTier  RiskTier
+		wantLimit Money
+	}{
+		{name: "just above minimum", salary: MustParseMoney("3000.01"), wantScore: 360, wantTier: RiskTierHigh, wantLimit: MustParseMoney("1800")},
+		{name: "first band", salary: MustParseMoney("5000"), wantScore: 400, wantTier: RiskTierHigh, wantLimit: MustParseMoney("3000")},
+		{name: "second band medium tier", salary: MustParseMoney("10000"), wantScore: 500, wantTier: RiskTierMedium, wantLimit: MustParseMoney("12000")},
+		{name: "third band low tier", salary: MustParseMoney("20000"), wantScore: 700, wantTier: RiskTierLow, wantLimit: MustParseMoney("40000")},
+		{name: "top band capped", salary: MustParseMoney("50000"), wantScore: 1000, wantTier: RiskTierLow, wantLimit: MustParseMoney("100000")},
+		{name: "rounds down to 100", salary: MustParseMoney("7777"), wantScore: 455, wantTier: RiskTierMedium, wantLimit: MustParseMoney("9300")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := ComputeOffer(&ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: tt.salary})
+			if offer.RiskScore != tt.wantScore {
+				t.Errorf("RiskScore = %d, want %d", offer.RiskScore, tt.wantScore)
+			}
+			if offer.RiskTier != tt.wantTier {
+				t.Errorf("RiskTier = %q, want %q", offer.RiskTier, tt.wantTier)
+			}
+			if offer.CreditLimit != tt.wantLimit {
+				t.Errorf("CreditLimit = %s, want %s", offer.CreditLimit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestAgeBandFor(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		birthDate string
+		want      AgeBand
+	}{
+		{name: "missing", birthDate: "", want: AgeBandUnknown},
+		{name: "malformed", birthDate: "10-03-2000", want: AgeBandUnknown},
+		{name: "18 years old", birthDate: "2007-03-10", want: AgeBandYoungAdult},
+		{name: "day before 25th birthday", birthDate: "2000-03-11", want: AgeBandYoungAdult},
+		{name: "25th birthday", birthDate: "2000-03-10", want: AgeBandAdult},
+		{name: "59 years old", birthDate: "1965-03-11", want: AgeBandAdult},
+		{name: "60th birthday", birthDate: "1965-03-10", want: AgeBandSenior},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AgeBandFor(&ProposalPayload{BirthDate: tt.birthDate}, now); got != tt.want {
+				t.Errorf("AgeBandFor(%q) = %q, want %q", tt.birthDate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeOfferAgeBands(t *testing.T) {
+	birthDate := func(years int) string {
+		return time.Now().AddDate(-years, 0, -1).Format(time.DateOnly)
+	}
+	tests := []struct {
+		name      string
+		birthDate string
+		wantLimit Money
+	}{
+		{name: "young adult", birthDate: birthDate(20), wantLimit: MustParseMoney("9600")},
+		{name: "adult", birthDate: birthDate(40), wantLimit: MustParseMoney("12000")},
+		{name: "senior", birthDate: birthDate(65), wantLimit: MustParseMoney("10800")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := ComputeOffer(&ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: MustParseMoney("10000"), BirthDate: tt.birthDate})
+			if offer.CreditLimit != tt.wantLimit {
+				t.Errorf("CreditLimit = %s, want %s", offer.CreditLimit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCreditOffer(t *testing.T) {
+	approved := AnalyzeCredit(&ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: MustParseMoney("5000")})
+	if approved.Offer == nil {
+		t.Fatal("expected approved credit analysis to carry an offer")
+	}
+
+	rejected := AnalyzeCredit(&ProposalPayload{CPF: "12345678902", FullName: "John Doe", Salary: MustParseMoney("2000")})
+	if rejected.Offer != nil {
+		t.Error("expected rejected credit analysis to carry no offer")
+	}
+}
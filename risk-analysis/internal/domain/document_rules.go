@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Document types account requires before requesting an analysis.
+const (
+	DocumentIdentity      = "identity"
+	DocumentProofOfIncome = "proof_of_income"
+)
+
+var RequiredDocumentTypes = []string{DocumentIdentity, DocumentProofOfIncome}
+
+// Limits for the uploaded files. Images are checked by their shorter and
+// longer side, so portrait and landscape photos are treated alike.
+const (
+	MinImageShortSide = 400
+	MinImageLongSide  = 600
+	MinPDFPages       = 1
+	MaxPDFPages       = 10
+)
+
+// analyzeUploadedDocuments checks the files in order: every required type is
+// present, no file was used by another CPF, and each image or PDF is legible.
+// The first failing check is reported.
+//
+// Unless account awaits the documents (ANALYSIS_AWAITS_DOCUMENTS), a proposal
+// is first analysed when it is created, before any upload. Such a request
+// carries no documents and skips these checks; account requests the analysis
+// again once the required documents are uploaded.
+func analyzeUploadedDocuments(documents []DocumentMetadata) AnalysisResult {
+	if len(documents) == 0 {
+		return NewApproved()
+	}
+
+	for _, required := range RequiredDocumentTypes {
+		if !hasDocumentType(documents, required) {
+			return NewRejected(ReasonDocumentMissing, fmt.Sprintf("%s document is missing", required))
+		}
+	}
+
+	for _, document := range documents {
+		if document.UsedByOtherCPF {
+			return NewRejected(ReasonDocumentReused, fmt.Sprintf("%s document was already used by another CPF", document.Type))
+		}
+	}
+
+	for _, document := range documents {
+		if result := analyzeFile(document); !result.Approved {
+			return result
+		}
+	}
+
+	return NewApproved()
+}
+
+func analyzeFile(document DocumentMetadata) AnalysisResult {
+	switch {
+	case strings.HasPrefix(document.ContentType, "image/"):
+		short, long := min(document.Width, document.Height), max(document.Width, document.Height)
+		if short < MinImageShortSide || long < MinImageLongSide {
+			return NewRejected(ReasonImageResolutionLow, fmt.Sprintf(
+				"%s image is %dx%d, it must be at least %dx%d",
+				document.Type, document.Width, document.Height, MinImageLongSide, MinImageShortSide,
+			))
+		}
+	case document.ContentType == "application/pdf":
+		if document.Pages < MinPDFPages || document.Pages > MaxPDFPages {
+			return NewRejected(ReasonPDFPageCountInvalid, fmt.Sprintf(
+				"%s PDF has %d pages, it must have %d to %d",
+				document.Type, document.Pages, MinPDFPages, MaxPDFPages,
+			))
+		}
+	}
+	return NewApproved()
+}
+
+func hasDocumentType(documents []DocumentMetadata, documentType string) bool {
+	for _, document := range documents {
+		if document.Type == documentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+// validDocuments returns documents that pass every document rule.
+func validDocuments() []DocumentMetadata {
+	return []DocumentMetadata{
+		{Type: DocumentIdentity, ContentType: "image/jpeg", Width: 1200, Height: 900},
+		{Type: DocumentProofOfIncome, ContentType: "application/pdf", Pages: 2},
+	}
+}
+
+func TestAnalyzeUploadedDocuments(t *testing.T) {
+	identity := func(width, height int) DocumentMetadata {
+		return DocumentMetadata{Type: DocumentIdentity, ContentType: "image/png", Width: width, Height: height}
+	}
+	income := func(pages int) DocumentMetadata {
+		return DocumentMetadata{Type: DocumentProofOfIncome, ContentType: "application/pdf", Pages: pages}
+	}
+	reused := func(d DocumentMetadata) DocumentMetadata {
+		d.UsedByOtherCPF = true
+		return d
+	}
+
+	tests := []struct {
+		name      string
+		documents []DocumentMetadata
+		wantCode  string
+	}{
+		{name: "valid documents", documents: validDocuments()},
+		{name: "portrait photo", documents: []DocumentMetadata{identity(600, 800), income(1)}},
+		{name: "smallest accepted image", documents: []DocumentMetadata{identity(600, 400), income(10)}},
+		{name: "image proof of income", documents: []DocumentMetadata{identity(800, 600), {Type: DocumentProofOfIncome, ContentType: "image/jpeg", Width: 800, Height: 600}}},
+		{name: "no documents uploaded yet", documents: nil},
+		{name: "identity missing", documents: []DocumentMetadata{income(1)}, wantCode: ReasonDocumentMissing},
+		{name: "proof of income missing", documents: []DocumentMetadata{identity(800, 600), identity(800, 600)}, wantCode: ReasonDocumentMissing},
+		{name: "file used by another CPF", documents: []DocumentMetadata{identity(800, 600), reused(income(1))}, wantCode: ReasonDocumentReused},
+		{name: "reuse is reported before resolution", documents: []DocumentMetadata{identity(100, 100), reused(income(1))}, wantCode: ReasonDocumentReused},
+		{name: "image shorter side too small", documents: []DocumentMetadata{identity(800, 399), income(1)}, wantCode: ReasonImageResolutionLow},
+		{name: "image longer side too small", documents: []DocumentMetadata{identity(599, 500), income(1)}, wantCode: ReasonImageResolutionLow},
+		{name: "unreadable image", documents: []DocumentMetadata{identity(0, 0), income(1)}, wantCode: ReasonImageResolutionLow},
+		{name: "unreadable PDF", documents: []DocumentMetadata{identity(800, 600), income(0)}, wantCode: ReasonPDFPageCountInvalid},
+		{name: "PDF with too many pages", documents: []DocumentMetadata{identity(800, 600), income(11)}, wantCode: ReasonPDFPageCountInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzeUploadedDocuments(tt.documents)
+			if result.Approved != (tt.wantCode == "") {
+				t.Errorf("analyzeUploadedDocuments() approved = %v, want %v", result.Approved, tt.wantCode == "")
+			}
+			if result.Code != tt.wantCode {
+				t.Errorf("analyzeUploadedDocuments().Code = %q, want %q", result.Code, tt.wantCode)
+			}
+			if tt.wantCode != "" && result.Reason == "" {
+				t.Error("expected a reason message with the reason code")
+			}
+		})
+	}
+}
+
+func TestAnalyzeDocumentsChecksFilesAfterApplicant(t *testing.T) {
+	payload := &ProposalPayload{CPF: "123", FullName: "John Doe"}
+	if result := AnalyzeDocuments(payload); result.Code != ReasonCPFInvalidLength {
+		t.Errorf("expected the CPF to be checked first, got %q", result.Code)
+	}
+
+	payload = &ProposalPayload{CPF: "12345678224", FullName: "John Doe", Documents: validDocuments()[:1]}
+	if result := AnalyzeDocuments(payload); result.Code != ReasonDocumentMissing {
+		t.Errorf("expected %s, got %q", ReasonDocumentMissing, result.Code)
+	}
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,10 +17,17 @@ import (
 // - Any changes to these values require coordinated deployment of both services
 //
 // Validation Flow:
-//  1. Documents: CPF length (11) and full name length (≥3)
-//  2. Credit: Salary threshold (>3000)
+//  1. Documents: CPF check digits (see cpf.go), full name length (≥3) and the
+//     uploaded files (see document_rules.go)
+//  2. Credit: Salary threshold (>3000), borderline salaries (2700-3000) go to review
 //  3. Fraud: CPF last digit parity check (even = approved)
 //  4. RiskAnalysisCompleted: Published when all validations pass
+//  5. ManualReviewRequired: Published instead of RiskAnalysisCompleted for a
+//     borderline case that passed the other validations
+//
+// Rejection events carry a reason_code (see analysis_rules.go) and a reason_message.
+// RiskAnalysisCompleted carries the credit offer (see credit_offer.go).
+// ManualReviewRequired carries both the reason and the offer the operator may approve.
 const (
 	EventDocumentsApproved     = "DocumentsApproved"
 	EventDocumentsRejected     = "DocumentsRejected"
@@ -27,6 +36,7 @@ const (
 	EventFraudApproved         = "FraudApproved"
 	EventFraudRejected         = "FraudRejected"
 	EventRiskAnalysisCompleted = "RiskAnalysisCompleted"
+	EventManualReviewRequired  = "ManualReviewRequired"
 )
 
 // Event type consumed by risk-analysis service from account.
@@ -35,16 +45,70 @@ const (
 )
 
 // ProposalStatusChangedEvent represents an outgoing event to account service.
+// ReasonCode and ReasonMessage are only set on rejection and review events;
+// the offer fields are only set on RiskAnalysisCompleted and
+// ManualReviewRequired.
+//
+// The credit limit is sent twice, like the salary: "credit_limit" as a
+// number in reais and "credit_limit_cents" as an integer.
 type ProposalStatusChangedEvent struct {
-	EventType  string    `json:"event_type"`
-	ProposalID uuid.UUID `json:"proposal_id"`
-	Approved   bool      `json:"approved"`
+	EventType        string    `json:"event_type"`
+	ProposalID       uuid.UUID `json:"proposal_id"`
+	Approved         bool      `json:"approved"`
+	ReasonCode       string    `json:"reason_code,omitempty"`
+	ReasonMessage    string    `json:"reason_message,omitempty"`
+	CreditLimit      Money     `json:"credit_limit,omitempty"`
+	CreditLimitCents int64     `json:"credit_limit_cents,omitempty"`
+	RiskTier         string    `json:"risk_tier,omitempty"`
+	RiskScore        int       `json:"risk_score,omitempty"`
 }
 
+// ProposalPayload is the applicant data sent by account. BirthDate is a
+// YYYY-MM-DD date; proposals created before it was added do not carry it.
+// Documents describes the files uploaded with the proposal; it is empty when
+// the analysis is requested before the documents are uploaded.
+//
+// account sends the salary twice: "salary" as a number in reais, which older
+// versions of both services use, and "salary_cents" as an integer. Salary is
+// taken from salary_cents when present.
 type ProposalPayload struct {
-	FullName string  `json:"full_name"`
-	CPF      string  `json:"cpf"`
-	Salary   float64 `json:"salary"`
+	FullName  string             `json:"full_name"`
+	CPF       string             `json:"cpf"`
+	Salary    Money              `json:"salary"`
+	BirthDate string             `json:"birth_date,omitempty"`
+	Documents []DocumentMetadata `json:"documents,omitempty"`
+}
+
+// DocumentMetadata describes an uploaded file; the content stays with
+// account. Width and Height are only sent for images and Pages for PDFs.
+// UsedByOtherCPF is set by account when the same file, by SHA256, was
+// uploaded for a proposal of another CPF.
+type DocumentMetadata struct {
+	ID             uuid.UUID `json:"id"`
+	Type           string    `json:"type"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	SHA256         string    `json:"sha256"`
+	Width          int       `json:"width,omitempty"`
+	Height         int       `json:"height,omitempty"`
+	Pages          int       `json:"pages,omitempty"`
+	UsedByOtherCPF bool      `json:"used_by_other_cpf"`
+}
+
+func (p *ProposalPayload) UnmarshalJSON(data []byte) error {
+	type payload ProposalPayload
+	var decoded struct {
+		payload
+		SalaryCents *int64 `json:"salary_cents"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = ProposalPayload(decoded.payload)
+	if decoded.SalaryCents != nil {
+		p.Salary = MoneyFromCents(*decoded.SalaryCents)
+	}
+	return nil
 }
 
 // ProposalCreatedEvent represents an incoming event from account service.
@@ -56,12 +120,13 @@ type ProposalCreatedEvent struct {
 }
 
 var (
-	ErrEmptyCPF       = errors.New("cpf is required")
-	ErrNilPayload     = errors.New("payload cannot be nil")
-	ErrEmptyFullName  = errors.New("full_name is required")
-	ErrNilProposalID  = errors.New("proposal_id cannot be nil")
-	ErrEmptyEventType = errors.New("event_type is required")
-	ErrNegativeSalary = errors.New("salary cannot be negative")
+	ErrEmptyCPF         = errors.New("cpf is required")
+	ErrNilPayload       = errors.New("payload cannot be nil")
+	ErrEmptyFullName    = errors.New("full_name is required")
+	ErrNilProposalID    = errors.New("proposal_id cannot be nil")
+	ErrEmptyEventType   = errors.New("event_type is required")
+	ErrNegativeSalary   = errors.New("salary cannot be negative")
+	ErrInvalidBirthDate = errors.New("birth_date must be a YYYY-MM-DD date")
 )
 
 func (e *ProposalCreatedEvent) Validate() error {
@@ -83,5 +148,10 @@ func (e *ProposalCreatedEvent) Validate() error {
 	if e.Payload.Salary < 0 {
 		return ErrNegativeSalary
 	}
+	if e.Payload.BirthDate != "" {
+		if _, err := time.Parse(time.DateOnly, e.Payload.BirthDate); err != nil {
+			return ErrInvalidBirthDate
+		}
+	}
 	return nil
 }
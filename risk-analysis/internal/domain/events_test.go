@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/uuid"
@@ -20,7 +21,7 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 				Payload: &ProposalPayload{
 					FullName: "John Doe",
 					CPF:      "12345678902",
-					Salary:   5000.0,
+					Salary:   MustParseMoney("5000.0"),
 				},
 			},
 			wantErr: nil,
@@ -42,7 +43,7 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 				Payload: &ProposalPayload{
 					FullName: "",
 					CPF:      "12345678902",
-					Salary:   5000.0,
+					Salary:   MustParseMoney("5000.0"),
 				},
 			},
 			wantErr: ErrEmptyFullName,
@@ -55,7 +56,7 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 				Payload: &ProposalPayload{
 					FullName: "John Doe",
 					CPF:      "",
-					Salary:   5000.0,
+					Salary:   MustParseMoney("5000.0"),
 				},
 			},
 			wantErr: ErrEmptyCPF,
@@ -68,7 +69,7 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 				Payload: &ProposalPayload{
 					FullName: "John Doe",
 					CPF:      "12345678902",
-					Salary:   -100.0,
+					Salary:   MustParseMoney("-100.0"),
 				},
 			},
 			wantErr: ErrNegativeSalary,
@@ -81,7 +82,35 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 				Payload: &ProposalPayload{
 					FullName: "John Doe",
 					CPF:      "12345678902",
-					Salary:   0.0,
+					Salary:   MustParseMoney("0.0"),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "malformed birth date",
+			event: &ProposalCreatedEvent{
+				EventType:  EventProposalCreated,
+				ProposalID: uuid.New(),
+				Payload: &ProposalPayload{
+					FullName:  "John Doe",
+					CPF:       "12345678902",
+					Salary:    MustParseMoney("5000.0"),
+					BirthDate: "15-01-1990",
+				},
+			},
+			wantErr: ErrInvalidBirthDate,
+		},
+		{
+			name: "ISO birth date is valid",
+			event: &ProposalCreatedEvent{
+				EventType:  EventProposalCreated,
+				ProposalID: uuid.New(),
+				Payload: &ProposalPayload{
+					FullName:  "John Doe",
+					CPF:       "12345678902",
+					Salary:    MustParseMoney("5000.0"),
+					BirthDate: "1990-01-15",
 				},
 			},
 			wantErr: nil,
@@ -97,3 +126,48 @@ func TestProposalCreatedEventValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestProposalPayloadSalaryDecoding(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want Money
+	}{
+		{name: "salary cents preferred", json: `{"salary": 3000.01, "salary_cents": 300001}`, want: 300001},
+		{name: "salary only, as sent by older account versions", json: `{"salary": 3000.01}`, want: 300001},
+		{name: "salary as string", json: `{"salary": "3000.01"}`, want: 300001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload ProposalPayload
+			if err := json.Unmarshal([]byte(tt.json), &payload); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if payload.Salary != tt.want {
+				t.Errorf("Salary = %d, want %d", payload.Salary, tt.want)
+			}
+		})
+	}
+}
+
+func TestProposalPayloadDocumentsDecoding(t *testing.T) {
+	data := `{"salary_cents": 500000, "documents": [
+		{"type": "identity", "content_type": "image/png", "sha256": "abc", "width": 800, "height": 600, "used_by_other_cpf": true},
+		{"type": "proof_of_income", "content_type": "application/pdf", "pages": 2, "used_by_other_cpf": false}
+	]}`
+
+	var payload ProposalPayload
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payload.Documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(payload.Documents))
+	}
+	if got := payload.Documents[0]; got.Type != DocumentIdentity || got.Width != 800 || got.Height != 600 || !got.UsedByOtherCPF {
+		t.Errorf("unexpected identity metadata %+v", got)
+	}
+	if got := payload.Documents[1]; got.Pages != 2 || got.UsedByOtherCPF {
+		t.Errorf("unexpected proof of income metadata %+v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidAmount = errors.New("amount must be a decimal number with at most 2 decimal places")
+
+// Money is an amount of Brazilian reais in centavos. It mirrors
+// account/internal/domain/money, so both services agree on the amounts
+// exchanged in events.
+type Money int64
+
+var centsPerReal = big.NewRat(100, 1)
+
+// decimalAmount is the only syntax accepted by ParseMoney. big.Rat also reads
+// fractions ("1/2") and hex or binary numbers, and huge exponents are costly
+// to expand, so the text is checked before it is parsed.
+var decimalAmount = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d{1,3})?$`)
+
+func MoneyFromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// ParseMoney reads a decimal amount in reais ("3000.01", "3000") without
+// going through float64. Amounts with fractions of a centavo are rejected.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if !decimalAmount.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+	amount, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidAmount
+	}
+	cents := amount.Mul(amount, centsPerReal)
+	if !cents.IsInt() || !cents.Num().IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return Money(cents.Num().Int64()), nil
+}
+
+// MustParseMoney is ParseMoney for constants; it panics on an invalid amount.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(fmt.Sprintf("money: %q: %v", s, err))
+	}
+	return m
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// String renders the amount in reais with two decimals, as in "3000.01".
+func (m Money) String() string {
+	sign, cents := "", int64(m)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON writes the amount as a JSON number in reais.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number (3000.01) or string
+// ("3000.01"). Numbers are parsed from their text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "3000.01", want: 300001},
+		{input: "3000", want: 300000},
+		{input: "0.1", want: 10},
+		{input: "-5.25", want: -525},
+		{input: "3000.001", wantErr: true},
+		{input: "3.000,01", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "1/2", wantErr: true},
+		{input: "0x10", wantErr: true},
+		{input: "0b1", wantErr: true},
+		{input: "1e999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Errorf("ParseMoney(%q) error = %v, want ErrInvalidAmount", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var amounts []Money
+	if err := json.Unmarshal([]byte(`[3000.01, "4500.50", 0.3]`), &amounts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if amounts[0] != 300001 || amounts[1] != 450050 || amounts[2] != 30 {
+		t.Errorf("unexpected amounts %v", amounts)
+	}
+
+	encoded, err := json.Marshal(amounts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(encoded) != `[3000.01,4500.50,0.30]` {
+		t.Errorf("unexpected JSON %s", encoded)
+	}
+}